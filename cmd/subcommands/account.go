@@ -4,25 +4,46 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/fatih/color"
+	"github.com/fbsobreira/gotron-sdk/pkg/account"
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/common/decimals"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys"
 	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/ledger"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
+	"github.com/fbsobreira/gotron-sdk/pkg/tip712"
 	"github.com/spf13/cobra"
 )
 
+// stake2DocsURL Stake 2.0 documentation shown by deprecated Stake 1.0 commands
+const stake2DocsURL = "https://developers.tron.network/docs/stake-20"
+
+// priceFeedClient fetches --price-feed, bounded so a stalled feed fails
+var priceFeedClient = &http.Client{Timeout: 10 * time.Second}
+
 var (
 	balanceDetails    bool
+	showCreatedAt     bool
 	resourcesType     int
 	resourcesDelegate string
+	legacyReceiver    string
+	legacyDuration    int
 	voteList          []string
 	permissionList    []string
+	tokenContracts    []string
+	priceFeed         string
 )
 
 func accountSub() []*cobra.Command {
@@ -97,7 +118,7 @@ func accountSub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -122,11 +143,10 @@ func accountSub() []*cobra.Command {
 				return fmt.Errorf("no signer specified")
 			}
 			// get amount
-			value, err := strconv.ParseFloat(args[1], 64)
+			valueInt, err := common.ParseAmountInt64(args[1], common.AmountDecimalPoint)
 			if err != nil {
 				return err
 			}
-			valueInt := int64(value * math.Pow10(6))
 			tx, err := conn.Transfer(signerAddress.String(), addr.String(), valueInt)
 			if err != nil {
 				return err
@@ -155,8 +175,8 @@ func accountSub() []*cobra.Command {
 			result := make(map[string]interface{})
 			result["from"] = signerAddress.String()
 			result["to"] = addr.String()
-			result["amount"] = value
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["amount"] = args[1]
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -219,11 +239,28 @@ func accountSub() []*cobra.Command {
 				return nil
 			}
 
-			asJSON, _ := json.Marshal(acc)
+			var output interface{} = acc
+			if showCreatedAt {
+				createdAt, activated, err := conn.GetAccountCreationTime(addr.String())
+				if err != nil {
+					return err
+				}
+				info := struct {
+					*account.Account
+					CreatedAt string `json:"createdAt,omitempty"`
+				}{Account: acc}
+				if activated {
+					info.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+				}
+				output = info
+			}
+
+			asJSON, _ := json.Marshal(output)
 			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
 			return nil
 		},
 	}
+	cmdInfo.Flags().BoolVar(&showCreatedAt, "show-created-at", false, "include account creation time")
 
 	cmdWithdraw := &cobra.Command{
 		Use:   "withdraw",
@@ -261,7 +298,7 @@ func accountSub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["address"] = addr.String()
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["amount"] = addr.String()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
@@ -287,11 +324,10 @@ func accountSub() []*cobra.Command {
 				return fmt.Errorf("no signer specified")
 			}
 			// get amount
-			value, err := strconv.ParseFloat(args[0], 64)
+			valueInt, err := common.ParseAmountInt64(args[0], common.AmountDecimalPoint)
 			if err != nil {
 				return err
 			}
-			valueInt := int64(value * math.Pow10(6))
 
 			delegateTo := ""
 			if len(resourcesDelegate) > 0 {
@@ -309,6 +345,10 @@ func accountSub() []*cobra.Command {
 				return fmt.Errorf("invalid resource. Use 0 for Bandwidth or 1 for Energy")
 			}
 
+			if err = checkMaxFreeze(rType, valueInt); err != nil {
+				return err
+			}
+
 			tx, err := conn.FreezeBalance(
 				signerAddress.String(),
 				delegateTo,
@@ -343,8 +383,8 @@ func accountSub() []*cobra.Command {
 			result["from"] = signerAddress.String()
 			result["Type"] = rType.String()
 			result["Delegate"] = resourcesDelegate
-			result["amount"] = value
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["amount"] = args[0]
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -358,6 +398,320 @@ func accountSub() []*cobra.Command {
 			return nil
 		},
 	}
+	cmdFreezeFor := &cobra.Command{
+		Use:   "freeze-for <RECEIVER> <AMOUNT> <BANDWIDTH|ENERGY>",
+		Short: "Stake TRX and delegate the resource to another account",
+		Long:  "Sends FreezeBalanceV2 then, once confirmed, DelegateResource of the same amount to the receiver",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			receiver, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			valueInt, err := common.ParseAmountInt64(args[1], common.AmountDecimalPoint)
+			if err != nil {
+				return err
+			}
+			var rType core.ResourceCode
+			switch strings.ToUpper(args[2]) {
+			case "BANDWIDTH":
+				rType = core.ResourceCode_BANDWIDTH
+			case "ENERGY":
+				rType = core.ResourceCode_ENERGY
+			default:
+				return fmt.Errorf("invalid resource %s, use BANDWIDTH or ENERGY", args[2])
+			}
+			if err = checkMaxFreeze(rType, valueInt); err != nil {
+				return err
+			}
+
+			var (
+				ks   *keystore.KeyStore
+				acct *keystore.Account
+			)
+			if useLedgerWallet {
+				acct = &keystore.Account{Address: signerAddress.GetAddress()}
+			} else if ks, acct, err = store.UnlockedKeystore(signerAddress.String(), passphrase); err != nil {
+				return err
+			}
+			// the delegation needs the stake, wait for it even with --no-wait
+			waitOpts := func(ctlr *transaction.Controller) {
+				opts(ctlr)
+				if ctlr.Behavior.ConfirmationWaitTime == 0 {
+					ctlr.Behavior.ConfirmationWaitTime = 60
+				}
+			}
+
+			freezeTx, err := conn.FreezeBalanceV2(signerAddress.String(), rType, valueInt)
+			if err != nil {
+				return err
+			}
+			freezeCtrlr := transaction.NewController(conn, ks, acct, freezeTx.Transaction,
+				waitOpts, transaction.WithPassphrase(passphrase))
+			if err = freezeCtrlr.ExecuteTransaction(); err != nil {
+				return err
+			}
+			freezeTxID, _ := freezeCtrlr.TransactionHash()
+			if dryRun {
+				fmt.Println("freeze txID:", freezeTxID)
+				return nil
+			}
+			if err = freezeCtrlr.GetResultError(); err != nil {
+				return fmt.Errorf("freeze %s failed: %v", freezeTxID, err)
+			}
+
+			delegateTx, err := conn.DelegateResource(signerAddress.String(), receiver.String(), rType, valueInt, false, 0)
+			if err != nil {
+				return fmt.Errorf("freeze %s confirmed, delegation not sent: %v", freezeTxID, err)
+			}
+			delegateCtrlr := transaction.NewController(conn, ks, acct, delegateTx.Transaction,
+				opts, transaction.WithPassphrase(passphrase))
+			if err = delegateCtrlr.ExecuteTransaction(); err != nil {
+				return fmt.Errorf("freeze %s confirmed, delegation failed: %v", freezeTxID, err)
+			}
+			delegateTxID, _ := delegateCtrlr.TransactionHash()
+
+			result := make(map[string]interface{})
+			result["from"] = signerAddress.String()
+			result["receiver"] = receiver.String()
+			result["type"] = rType.String()
+			result["amount"] = float64(valueInt) / 1000000
+			result["freezeTxID"] = freezeTxID
+			result["delegateTxID"] = delegateTxID
+			result["success"] = delegateCtrlr.GetResultError() == nil
+
+			asJSON, _ := json.Marshal(result)
+			if noPrettyOutput {
+				fmt.Println(string(asJSON))
+				return nil
+			}
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	var restakeAmount string
+	cmdRestakeDelegate := &cobra.Command{
+		Use:   "restake-delegate <RECEIVER> <BANDWIDTH|ENERGY>",
+		Short: "Cancel pending unfreezes and delegate the restaked resource",
+		Long:  "Sends CancelAllUnfreezeV2 then, once confirmed, DelegateResource of --amount or of what was pending for the resource",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			receiver, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			var rType core.ResourceCode
+			switch strings.ToUpper(args[1]) {
+			case "BANDWIDTH":
+				rType = core.ResourceCode_BANDWIDTH
+			case "ENERGY":
+				rType = core.ResourceCode_ENERGY
+			default:
+				return fmt.Errorf("invalid resource %s, use BANDWIDTH or ENERGY", args[1])
+			}
+			var amount int64
+			if restakeAmount != "" {
+				if amount, err = common.ParseAmountInt64(restakeAmount, common.AmountDecimalPoint); err != nil {
+					return err
+				}
+			}
+
+			var (
+				ks   *keystore.KeyStore
+				acct *keystore.Account
+			)
+			if useLedgerWallet {
+				acct = &keystore.Account{Address: signerAddress.GetAddress()}
+			} else if ks, acct, err = store.UnlockedKeystore(signerAddress.String(), passphrase); err != nil {
+				return err
+			}
+			restake, err := transaction.CancelUnfreezeAndDelegate(conn, ks, acct, receiver.String(), rType, amount,
+				opts, transaction.WithPassphrase(passphrase))
+			if err != nil {
+				return err
+			}
+
+			result := make(map[string]interface{})
+			result["from"] = signerAddress.String()
+			result["receiver"] = receiver.String()
+			result["type"] = rType.String()
+			result["amount"] = float64(restake.Amount) / 1000000
+			result["cancelTxID"] = restake.CancelTxID
+			result["delegateTxID"] = restake.DelegateTxID
+
+			asJSON, _ := json.Marshal(result)
+			if noPrettyOutput {
+				fmt.Println(string(asJSON))
+				return nil
+			}
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdRestakeDelegate.Flags().StringVar(&restakeAmount, "amount", "", "TRX to delegate, defaults to the pending unfreezes of the resource")
+
+	cmdLegacyUnstake := &cobra.Command{
+		Use:   "legacy-unstake <BANDWIDTH|ENERGY>",
+		Short: "Unfreeze a Stake 1.0 balance",
+		Long: "Unfreeze TRX frozen with the legacy Stake 1.0 FreezeBalance, optionally the part delegated to --receiver.\n" +
+			"Balances staked with Stake 2.0 are released with UnfreezeBalanceV2 and do not apply here.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			var rType core.ResourceCode
+			switch strings.ToUpper(args[0]) {
+			case "BANDWIDTH":
+				rType = core.ResourceCode_BANDWIDTH
+			case "ENERGY":
+				rType = core.ResourceCode_ENERGY
+			default:
+				return fmt.Errorf("invalid resource %s, use BANDWIDTH or ENERGY", args[0])
+			}
+			receiver := ""
+			if len(legacyReceiver) > 0 {
+				receiverAddr, err := findAddress(legacyReceiver)
+				if err != nil {
+					return fmt.Errorf("invalid receiver address %s. %+v", legacyReceiver, err)
+				}
+				receiver = receiverAddr.String()
+			}
+
+			tx, err := conn.LegacyUnfreezeBalance(signerAddress.String(), rType, receiver)
+			if err != nil {
+				return err
+			}
+
+			var ctrlr *transaction.Controller
+			if useLedgerWallet {
+				account := keystore.Account{Address: signerAddress.GetAddress()}
+				ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+				if err != nil {
+					return err
+				}
+				ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+			}
+			if err = ctrlr.ExecuteTransaction(); err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(tx, ctrlr.Receipt, ctrlr.Result)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["from"] = signerAddress.String()
+			result["type"] = rType.String()
+			result["receiver"] = receiver
+			result["txID"], _ = ctrlr.TransactionHash()
+			result["blockNumber"] = ctrlr.Receipt.BlockNumber
+			result["message"] = string(ctrlr.Result.Message)
+			result["receipt"] = map[string]interface{}{
+				"fee":      ctrlr.Receipt.Fee,
+				"netFee":   ctrlr.Receipt.Receipt.NetFee,
+				"netUsage": ctrlr.Receipt.Receipt.NetUsage,
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdLegacyUnstake.Flags().StringVar(&legacyReceiver, "receiver", "", "Address the balance was delegated to")
+
+	cmdLegacyStake := &cobra.Command{
+		Use:   "legacy-stake <AMOUNT> <BANDWIDTH|ENERGY>",
+		Short: "Freeze TRX with the deprecated Stake 1.0 contract",
+		Long: "Freeze TRX with the legacy Stake 1.0 FreezeBalance, optionally delegating the resource to --receiver.\n" +
+			"Deprecated: networks running Stake 2.0 reject it, see " + stake2DocsURL,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintln(os.Stderr, color.YellowString(
+				"Warning: Stake 1.0 is deprecated, use Stake 2.0 instead: %s", stake2DocsURL))
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			valueInt, err := common.ParseAmountInt64(args[0], common.AmountDecimalPoint)
+			if err != nil {
+				return err
+			}
+			var rType core.ResourceCode
+			switch strings.ToUpper(args[1]) {
+			case "BANDWIDTH":
+				rType = core.ResourceCode_BANDWIDTH
+			case "ENERGY":
+				rType = core.ResourceCode_ENERGY
+			default:
+				return fmt.Errorf("invalid resource %s, use BANDWIDTH or ENERGY", args[1])
+			}
+			receiver := ""
+			if len(legacyReceiver) > 0 {
+				receiverAddr, err := findAddress(legacyReceiver)
+				if err != nil {
+					return fmt.Errorf("invalid receiver address %s. %+v", legacyReceiver, err)
+				}
+				receiver = receiverAddr.String()
+			}
+
+			tx, err := conn.LegacyFreezeBalance(signerAddress.String(), valueInt, legacyDuration, rType, receiver)
+			if err != nil {
+				return err
+			}
+
+			var ctrlr *transaction.Controller
+			if useLedgerWallet {
+				account := keystore.Account{Address: signerAddress.GetAddress()}
+				ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+				if err != nil {
+					return err
+				}
+				ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+			}
+			if err = ctrlr.ExecuteTransaction(); err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(tx, ctrlr.Receipt, ctrlr.Result)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["from"] = signerAddress.String()
+			result["amount"] = valueInt
+			result["duration"] = legacyDuration
+			result["type"] = rType.String()
+			result["receiver"] = receiver
+			result["txID"], _ = ctrlr.TransactionHash()
+			result["blockNumber"] = ctrlr.Receipt.BlockNumber
+			result["message"] = string(ctrlr.Result.Message)
+			result["receipt"] = map[string]interface{}{
+				"fee":      ctrlr.Receipt.Fee,
+				"netFee":   ctrlr.Receipt.Receipt.NetFee,
+				"netUsage": ctrlr.Receipt.Receipt.NetUsage,
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdLegacyStake.Flags().StringVar(&legacyReceiver, "receiver", "", "Address to delegate the resource to")
+	cmdLegacyStake.Flags().IntVar(&legacyDuration, "duration", 3, "days the balance stays frozen")
+
 	cmdFreeze.Flags().IntVarP(&resourcesType, "type", "t", 0, "0 - Bandwidth / 1 - Energy")
 	cmdFreeze.Flags().StringVar(&resourcesDelegate, "delegate", "", "Delegate to address")
 
@@ -418,7 +772,7 @@ func accountSub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["from"] = signerAddress.String()
 			result["votes"] = votes
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
@@ -436,6 +790,71 @@ func accountSub() []*cobra.Command {
 	}
 	cmdVote.Flags().StringSliceVar(&voteList, "wv", []string{}, "witness1:vote1,witness2:vote2")
 
+	cmdVoteAll := &cobra.Command{
+		Use:   "vote-all <WITNESS>",
+		Short: "vote all available tron power for a single witness",
+		Long:  "Votes replace the account previous votes, all frozen TRX (1 vote per TRX) goes to WITNESS",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			witness, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			acc, err := conn.GetAccountDetailed(signerAddress.String())
+			if err != nil {
+				return err
+			}
+			if acc.TronPower <= 0 {
+				return fmt.Errorf("no tron power available, freeze TRX first")
+			}
+			votes := map[string]int64{witness.String(): acc.TronPower}
+
+			tx, err := conn.VoteWitnessAccount(signerAddress.String(), votes)
+			if err != nil {
+				return err
+			}
+
+			var ctrlr *transaction.Controller
+			if useLedgerWallet {
+				account := keystore.Account{Address: signerAddress.GetAddress()}
+				ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+				if err != nil {
+					return err
+				}
+				ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+			}
+			if err = ctrlr.ExecuteTransaction(); err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(tx)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["txID"], _ = ctrlr.TransactionHash()
+			result["from"] = signerAddress.String()
+			result["votes"] = votes
+			result["blockNumber"] = ctrlr.Receipt.BlockNumber
+			result["message"] = string(ctrlr.Result.Message)
+			result["receipt"] = map[string]interface{}{
+				"fee":      ctrlr.Receipt.Fee,
+				"netFee":   ctrlr.Receipt.Receipt.NetFee,
+				"netUsage": ctrlr.Receipt.Receipt.NetUsage,
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
 	cmdPermission := &cobra.Command{
 		Use:   "permission",
 		Short: "Update account permission",
@@ -592,7 +1011,7 @@ func accountSub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["from"] = signerAddress.String()
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -690,7 +1109,184 @@ func accountSub() []*cobra.Command {
 	cmdVerify.Flags().BoolVar(&useFixedLength, "useFixedLength", false, "--useFixedLength=true")
 	cmdVerify.Flags().BoolVar(&hashMessage, "hashMessage", false, "--hashMessage=true")
 
-	return []*cobra.Command{cmdBalance, cmdActivate, cmdSend, cmdAddress, cmdInfo, cmdWithdraw, cmdFreeze, cmdVote, cmdPermission, cmdSign, cmdVerify}
+	var typedDataFile string
+	cmdSignTypedData := &cobra.Command{
+		Use:   "sign-typed-data",
+		Short: "sign TIP-712 typed data",
+		Long:  "Sign a typed data JSON file as TronLink signTypedData does, with --ledger the Tron app signs it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" && !useLedgerWallet {
+				return fmt.Errorf("no signer specified")
+			}
+			if typedDataFile == "" {
+				return fmt.Errorf("no typed data file specified")
+			}
+			data, err := ioutil.ReadFile(typedDataFile)
+			if err != nil {
+				return err
+			}
+			typed, err := tip712.ParseTypedData(data)
+			if err != nil {
+				return err
+			}
+			domainSeparator, messageHash, err := tip712.HashTypedDataParts(typed.Domain, typed.Types,
+				typed.PrimaryType, typed.Message)
+			if err != nil {
+				return err
+			}
+			hash, err := typed.Hash()
+			if err != nil {
+				return err
+			}
+
+			var (
+				signature []byte
+				signer    = signerAddress.String()
+			)
+			if useLedgerWallet {
+				// the Tron app signs with its first account whatever --signer is
+				device := ledger.GetAddress()
+				if signer != "" && signer != device {
+					return fmt.Errorf("ledger account %s does not match signer %s", device, signer)
+				}
+				signer = device
+				if signature, err = ledger.SignTypedDataHash(domainSeparator, messageHash); err != nil {
+					return err
+				}
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signer, passphrase)
+				if err != nil {
+					return err
+				}
+				_, key, err := ks.GetDecryptedKey(*acct, passphrase)
+				if err != nil {
+					return err
+				}
+				if signature, err = keys.SignTypedData(key.PrivateKey, typed); err != nil {
+					return err
+				}
+			}
+
+			if noPrettyOutput {
+				fmt.Println(common.BytesToHexString(signature))
+				return nil
+			}
+			result := make(map[string]interface{})
+			result["Signer"] = signer
+			result["Hash"] = common.BytesToHexString(hash)
+			result["Signature"] = common.BytesToHexString(signature)
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdSignTypedData.Flags().StringVar(&typedDataFile, "file", "", "typed data JSON file")
+
+	cmdVotes := &cobra.Command{
+		Use:     "votes <ACCOUNT_NAME>",
+		Short:   "List votes cast by an account and its voting power",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: validateAddress,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			votes, err := conn.GetVoteList(addr.String())
+			if err != nil {
+				return err
+			}
+			used, available, err := conn.GetVotingPower(addr.String())
+			if err != nil {
+				return err
+			}
+
+			result := make(map[string]interface{})
+			result["address"] = addr.String()
+			result["votes"] = votes
+			result["votingPowerUsed"] = used
+			result["votingPowerAvailable"] = available
+			result["votingPowerUnused"] = available - used
+
+			asJSON, _ := json.Marshal(result)
+			if noPrettyOutput {
+				fmt.Println(string(asJSON))
+				return nil
+			}
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	cmdTokens := &cobra.Command{
+		Use:     "tokens <ACCOUNT_NAME>",
+		Short:   "List TRC10 and TRC20 tokens held by an account",
+		Long:    "Lists TRC10 assets and the TRC20 contracts given with --trc20. --price-feed is a URL serving a JSON object of USD prices by token symbol",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: validateAddress,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var prices map[string]float64
+			if priceFeed != "" {
+				resp, err := priceFeedClient.Get(priceFeed)
+				if err != nil {
+					return fmt.Errorf("price feed: %v", err)
+				}
+				defer resp.Body.Close()
+				if resp.StatusCode != http.StatusOK {
+					return fmt.Errorf("price feed: %s", resp.Status)
+				}
+				if err = json.NewDecoder(resp.Body).Decode(&prices); err != nil {
+					return fmt.Errorf("price feed: %v", err)
+				}
+			}
+
+			conn.SetTRC20Tokens(tokenContracts)
+			tokens, err := conn.GetAccountTokens(addr.String())
+			if err != nil {
+				return err
+			}
+
+			result := make([]map[string]interface{}, 0, len(tokens))
+			for _, token := range tokens {
+				amount := new(big.Float).Quo(new(big.Float).SetInt(token.Balance),
+					decimals.Pow(decimals.NewFloat(10), int64(token.Decimals)))
+				entry := map[string]interface{}{
+					"type":    token.Type,
+					"tokenId": token.TokenID,
+					"symbol":  token.Symbol,
+					"balance": amount.Text('f', int(token.Decimals)),
+				}
+				if price, ok := prices[token.Symbol]; ok {
+					usd, _ := new(big.Float).Mul(amount, big.NewFloat(price)).Float64()
+					entry["usdValue"] = usd
+				}
+				result = append(result, entry)
+			}
+
+			asJSON, _ := json.Marshal(result)
+			if noPrettyOutput {
+				fmt.Println(string(asJSON))
+				return nil
+			}
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdTokens.Flags().StringSliceVar(&tokenContracts, "trc20", []string{}, "TRC20 contract addresses to check")
+	cmdTokens.Flags().StringVar(&priceFeed, "price-feed", "", "URL of a JSON object of USD prices by symbol")
+
+	return []*cobra.Command{cmdBalance, cmdActivate, cmdSend, cmdAddress, cmdInfo, cmdWithdraw, cmdFreeze, cmdFreezeFor, cmdRestakeDelegate, cmdLegacyStake, cmdLegacyUnstake, cmdVote, cmdVoteAll, cmdPermission, cmdSign, cmdVerify, cmdSignTypedData, cmdTokens, cmdVotes}
+}
+
+// checkMaxFreeze refuses to stake more than the signer can afford while
+// keeping TRX for later fees
+func checkMaxFreeze(resource core.ResourceCode, amount int64) error {
+	limit, err := conn.GetMaxFreezeAmount(signerAddress.String(), resource)
+	if err != nil {
+		return err
+	}
+	if amount > limit {
+		return fmt.Errorf("amount %.6f TRX exceeds the %.6f TRX that can be staked for %s, 1 TRX is kept for fees",
+			float64(amount)/1000000, float64(limit)/1000000, resource.String())
+	}
+	return nil
 }
 
 func init() {
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/spf13/cobra"
+)
+
+func blockSub() []*cobra.Command {
+	cmdSignature := &cobra.Command{
+		Use:   "signature <BLOCK_NUMBER>",
+		Short: "recover the SR that signed a block",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			num, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid block number %s: %v", args[0], err)
+			}
+			block, err := conn.GetBlockByNum(num)
+			if err != nil {
+				return err
+			}
+			signer, err := client.RecoverBlockSigner(block)
+			if err != nil {
+				return err
+			}
+			witness := address.Address(block.GetBlockHeader().GetRawData().GetWitnessAddress())
+
+			witnesses, err := conn.ListWitnesses()
+			if err != nil {
+				return err
+			}
+			isWitness := false
+			for _, w := range witnesses.GetWitnesses() {
+				if bytes.Equal(w.GetAddress(), witness) {
+					isWitness = true
+					break
+				}
+			}
+
+			if noPrettyOutput {
+				fmt.Println(signer)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["blockNumber"] = num
+			result["blockID"] = common.BytesToHexString(block.GetBlockid())
+			result["witnessAddress"] = witness.String()
+			result["signer"] = signer
+			result["signerMatchesWitness"] = signer == witness.String()
+			result["isWitness"] = isWitness
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	return []*cobra.Command{cmdSignature}
+}
+
+func init() {
+	cmdBlock := &cobra.Command{
+		Use:   "block",
+		Short: "Block Actions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdBlock.AddCommand(blockSub()...)
+	RootCmd.AddCommand(cmdBlock)
+}
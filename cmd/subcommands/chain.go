@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/spf13/cobra"
+)
+
+// expirationWarning transactions expiring sooner are reported as expiring
+const expirationWarning = 30 * time.Second
+
+var (
+	oracleAddress string
+	priceFormat   string
+)
+
+// printPriceHistory outputs the current price and its history, points hold
+// block number and price pairs
+func printPriceHistory(current int64, points [][2]int64) error {
+	switch priceFormat {
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"block", "price"})
+		for _, p := range points {
+			w.Write([]string{strconv.FormatInt(p[0], 10), strconv.FormatInt(p[1], 10)})
+		}
+		w.Flush()
+		return w.Error()
+	case "json":
+	default:
+		return fmt.Errorf("invalid format %s, expected json or csv", priceFormat)
+	}
+
+	history := make([]map[string]int64, 0, len(points))
+	for _, p := range points {
+		history = append(history, map[string]int64{"block": p[0], "price": p[1]})
+	}
+	result := make(map[string]interface{})
+	result["current"] = current
+	result["history"] = history
+
+	asJSON, _ := json.Marshal(result)
+	if noPrettyOutput {
+		fmt.Println(string(asJSON))
+		return nil
+	}
+	fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+	return nil
+}
+
+func chainSub() []*cobra.Command {
+	cmdPrice := &cobra.Command{
+		Use:   "price <SYMBOL>",
+		Short: "get USD reference price from a Band Protocol oracle",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if oracleAddress == "" {
+				return fmt.Errorf("no oracle specified, set --oracle")
+			}
+			oracle, err := findAddress(oracleAddress)
+			if err != nil {
+				return err
+			}
+			symbol := strings.ToUpper(args[0])
+			prices, err := conn.GetPriceOracleAt(oracle.String(), symbol)
+			if err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(prices[symbol])
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["symbol"] = symbol
+			result["price"] = float64(prices[symbol]) / 1e8
+			result["priceRaw"] = prices[symbol]
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdPrice.Flags().StringVar(&oracleAddress, "oracle", client.BandOracleMainnet, "Band Protocol StdReference contract address")
+
+	cmdSupply := &cobra.Command{
+		Use:   "supply",
+		Short: "get estimated TRX total, frozen and circulating supply",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			supply, err := conn.GetSupply()
+			if err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(supply.Circulating)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["genesis"] = float64(supply.Genesis) / 1000000
+			result["minted"] = float64(supply.Minted) / 1000000
+			result["burned"] = float64(supply.Burned) / 1000000
+			result["total"] = float64(supply.Total) / 1000000
+			result["frozen"] = float64(supply.Frozen) / 1000000
+			result["circulating"] = float64(supply.Circulating) / 1000000
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	cmdReplay := &cobra.Command{
+		Use:   "replay-protection-check <TX_HEX>",
+		Short: "check signed transaction reference block and expiration against the chain head",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tx, err := decodeTransactionHex(args[0])
+			if err != nil {
+				return err
+			}
+			raw := tx.GetRawData()
+			if raw == nil || len(raw.RefBlockBytes) != 2 {
+				return fmt.Errorf("invalid transaction: missing reference block")
+			}
+
+			head, err := conn.GetNowBlock()
+			if err != nil {
+				return err
+			}
+			headNum := head.GetBlockHeader().GetRawData().GetNumber()
+			headTime := time.UnixMilli(head.GetBlockHeader().GetRawData().GetTimestamp())
+			expiration := time.UnixMilli(raw.Expiration)
+
+			// the node accepts references to the last 65536 blocks, matched by
+			// the low two bytes of the number and bytes 8 to 16 of the hash
+			refNum := headNum&^0xffff | int64(binary.BigEndian.Uint16(raw.RefBlockBytes))
+			if refNum > headNum {
+				refNum -= 0x10000
+			}
+			refValid := false
+			if refNum >= 0 {
+				refBlock, err := conn.GetBlockByNum(refNum)
+				if err != nil {
+					return err
+				}
+				id := refBlock.GetBlockid()
+				refValid = len(id) == 32 && bytes.Equal(id[8:16], raw.RefBlockHash)
+			}
+
+			remaining := expiration.Sub(headTime)
+			status := "valid"
+			switch {
+			case remaining <= 0:
+				status = "expired"
+			case !refValid:
+				status = "invalid reference block"
+			case remaining <= expirationWarning:
+				status = "expiring"
+			}
+
+			if noPrettyOutput {
+				fmt.Println(status)
+			} else {
+				result := make(map[string]interface{})
+				result["status"] = status
+				result["refBlockNumber"] = refNum
+				result["refBlockValid"] = refValid
+				result["expiration"] = expiration.UTC().Format(time.RFC3339)
+				result["headBlockNumber"] = headNum
+				result["headBlockTime"] = headTime.UTC().Format(time.RFC3339)
+				result["remainingSeconds"] = int64(remaining.Seconds())
+
+				asJSON, _ := json.Marshal(result)
+				fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			}
+
+			if status == "expired" || !refValid {
+				return fmt.Errorf("transaction can not be broadcast: %s", status)
+			}
+			return nil
+		},
+	}
+
+	cmdNextMaintenance := &cobra.Command{
+		Use:   "next-maintenance",
+		Short: "show countdown to the next maintenance period and the last maintenance block",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			next, err := conn.NextMaintenanceTime()
+			if err != nil {
+				return err
+			}
+			lastBlock, err := conn.LastMaintenanceBlock()
+			if err != nil {
+				return err
+			}
+			countdown := time.Until(next).Round(time.Second)
+			if countdown < 0 {
+				countdown = 0
+			}
+
+			if noPrettyOutput {
+				fmt.Println(next.UTC().Format(time.RFC3339), countdown, lastBlock)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["nextMaintenance"] = next.UTC().Format(time.RFC3339)
+			result["nextTimestamp"] = next.UnixMilli()
+			result["countdown"] = countdown.String()
+			result["lastMaintenanceBlock"] = lastBlock
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	cmdBandwidthPrice := &cobra.Command{
+		Use:   "bandwidth-price",
+		Short: "show SUN burned per byte of bandwidth and how it changed over time",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current, err := conn.GetCurrentBandwidthPrice()
+			if err != nil {
+				return err
+			}
+			history, err := conn.GetBandwidthPriceHistory()
+			if err != nil {
+				return err
+			}
+			points := make([][2]int64, 0, len(history))
+			for _, p := range history {
+				points = append(points, [2]int64{p.BlockNum, p.PriceInSunPerByte})
+			}
+			return printPriceHistory(current, points)
+		},
+	}
+	cmdBandwidthPrice.Flags().StringVar(&priceFormat, "format", "json", "output format: json or csv")
+
+	cmdEnergyPrice := &cobra.Command{
+		Use:   "energy-price",
+		Short: "show SUN burned per unit of energy and how it changed over time",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current, err := conn.GetCurrentEnergyPrice()
+			if err != nil {
+				return err
+			}
+			history, err := conn.GetEnergyPriceHistory()
+			if err != nil {
+				return err
+			}
+			points := make([][2]int64, 0, len(history))
+			for _, p := range history {
+				points = append(points, [2]int64{p.BlockNum, p.PriceInSunPerEnergy})
+			}
+			return printPriceHistory(current, points)
+		},
+	}
+	cmdEnergyPrice.Flags().StringVar(&priceFormat, "format", "json", "output format: json or csv")
+
+	cmdID := &cobra.Command{
+		Use:   "id",
+		Short: "show the chain ID of the connected network, the last 4 bytes of its genesis block hash",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := conn.GetChainIDHex()
+			if err != nil {
+				return err
+			}
+			fmt.Println(id)
+			return nil
+		},
+	}
+
+	cmdStats := &cobra.Command{
+		Use:   "stats",
+		Short: "show total transactions, current TPS and network resource totals",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stats, err := conn.GetNetworkStats()
+			if err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Printf("%d\t%d\t%.2f\n", stats.BlockNum, stats.TotalTransactions, stats.TPS)
+				return nil
+			}
+
+			asJSON, _ := json.Marshal(stats)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	return []*cobra.Command{cmdPrice, cmdSupply, cmdReplay, cmdNextMaintenance, cmdBandwidthPrice, cmdEnergyPrice, cmdID, cmdStats}
+}
+
+func init() {
+	cmdChain := &cobra.Command{
+		Use:   "chain",
+		Short: "Network wide information",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdChain.AddCommand(chainSub()...)
+	RootCmd.AddCommand(cmdChain)
+}
@@ -1,16 +1,21 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"math"
+	"strings"
 
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/contract"
 	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
 
 	"github.com/spf13/cobra"
@@ -24,12 +29,34 @@ var (
 	feeLimit     int64
 	curPercent   int64
 	oeLimit      int64
-	tAmount      float64
+	tAmount      string
 	tTokenID     string
-	tTokenAmount float64
+	tTokenAmount string
 	estimate     bool
+	eventsBlock  int64
+	inputData    string
+
+	destructBeneficiary string
+	destructMethod      string
+	destructFeeLimit    int64
+
+	bytecodeOut string
+	bytecodeHex bool
+	callValue   int64
+
+	upgradeInitData string
+	upgradeFeeLimit int64
 )
 
+// loadABIFile reads a JSON ABI file into its proto representation
+func loadABIFile(file string) (*core.SmartContract_ABI, error) {
+	abiBytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ABI file: %s %v", file, err)
+	}
+	return contract.JSONtoABI(string(abiBytes))
+}
+
 func contractSub() []*cobra.Command {
 	cmdDeploy := &cobra.Command{
 		Use:   "deploy <CONTRACT_NAME>",
@@ -96,10 +123,16 @@ func contractSub() []*cobra.Command {
 				return nil
 			}
 
+			txID, _ := ctrlr.TransactionHash()
 			addrResult := address.Address(ctrlr.Receipt.ContractAddress).String()
+			if len(ctrlr.Receipt.ContractAddress) == 0 {
+				// not confirmed yet, the address is known from the transaction
+				txIDBytes, _ := common.FromHex(txID)
+				addrResult = address.ComputeContractAddress(signerAddress.GetAddress(), txIDBytes).String()
+			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"] = txID
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["contractAddress"] = addrResult
@@ -184,18 +217,33 @@ func contractSub() []*cobra.Command {
 				return fmt.Errorf("no signer specified")
 			}
 			// get amount
-			valueInt := int64(0)
-			if tAmount > 0 {
-				valueInt = int64(tAmount * math.Pow10(6))
+			var (
+				valueInt int64
+				err      error
+			)
+			if tAmount != "" {
+				if valueInt, err = common.ParseAmountInt64(tAmount, common.AmountDecimalPoint); err != nil {
+					return err
+				}
+			}
+			ctrlrOpts := []func(*transaction.Controller){opts}
+			if callValue > 0 {
+				if valueInt > 0 {
+					return fmt.Errorf("--value and --call-value can not be used together")
+				}
+				valueInt = callValue
+				ctrlrOpts = append(ctrlrOpts, transaction.WithCallValue(callValue))
 			}
 			tokenInt := int64(0)
-			if tTokenAmount > 0 {
+			if tTokenAmount != "" {
 				// get token info
 				info, err := conn.GetAssetIssueByID(tTokenID)
 				if err != nil {
 					return err
 				}
-				tokenInt = int64(tAmount * math.Pow10(int(info.Precision)))
+				if tokenInt, err = common.ParseAmountInt64(tTokenAmount, int(info.Precision)); err != nil {
+					return err
+				}
 			}
 
 			param := ""
@@ -252,13 +300,13 @@ func contractSub() []*cobra.Command {
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
-				ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+				ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, ctrlrOpts...)
 			} else {
 				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
 				if err != nil {
 					return err
 				}
-				ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+				ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, ctrlrOpts...)
 			}
 			if err = ctrlr.ExecuteTransaction(); err != nil {
 				return err
@@ -272,7 +320,7 @@ func contractSub() []*cobra.Command {
 			addrResult := address.Address(ctrlr.Receipt.ContractAddress).String()
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["contractAddress"] = addrResult
@@ -295,12 +343,342 @@ func contractSub() []*cobra.Command {
 		},
 	}
 	cmdTrigger.Flags().Int64Var(&feeLimit, "feeLimit", 10000000, "fee limit")
-	cmdTrigger.Flags().Float64Var(&tAmount, "value", 0, "trx amount")
+	cmdTrigger.Flags().StringVar(&tAmount, "value", "", "trx amount")
+	cmdTrigger.Flags().Int64Var(&callValue, "call-value", 0, "SUN sent to a payable method, checked against the signer balance")
 	cmdTrigger.Flags().StringVar(&tTokenID, "token", "", "token id")
-	cmdTrigger.Flags().Float64Var(&tTokenAmount, "tokenValue", 0, "token amount")
+	cmdTrigger.Flags().StringVar(&tTokenAmount, "tokenValue", "", "token amount")
 	cmdTrigger.Flags().BoolVar(&estimate, "estiamte", false, "estimate energy required")
 
-	return []*cobra.Command{cmdDeploy, cmdConstant, cmdTrigger}
+	cmdEvents := &cobra.Command{
+		Use:   "events <CONTRACT_ADDRESS>",
+		Short: "list events emitted by a contract in a block",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contractAddress, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("block") {
+				return fmt.Errorf("no block specified")
+			}
+			var ABI *core.SmartContract_ABI
+			if abiFile != "" {
+				if ABI, err = loadABIFile(abiFile); err != nil {
+					return err
+				}
+			}
+
+			infos, err := conn.GetBlockInfoByNum(eventsBlock)
+			if err != nil {
+				return err
+			}
+			contractBytes := contractAddress.GetAddress().Bytes()[1:]
+
+			events := make([]map[string]interface{}, 0)
+			for _, info := range infos.GetTransactionInfo() {
+				for i, log := range info.GetLog() {
+					if !bytes.Equal(log.GetAddress(), contractBytes) {
+						continue
+					}
+					event := map[string]interface{}{
+						"txID":     common.BytesToHexString(info.GetId()),
+						"logIndex": i,
+					}
+					if decoded, err := abi.DecodeEvent(ABI, log); err == nil {
+						event["event"] = decoded.Name
+						event["params"] = decoded.Params
+					} else {
+						event["topics"] = common.ToHexArray(log.GetTopics())
+						event["data"] = common.BytesToHexString(log.GetData())
+					}
+					events = append(events, event)
+				}
+			}
+
+			asJSON, _ := json.Marshal(events)
+			if noPrettyOutput {
+				fmt.Println(string(asJSON))
+			} else {
+				fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			}
+			fmt.Printf("%d event(s) from %s in block %d\n", len(events), contractAddress.String(), eventsBlock)
+			return nil
+		},
+	}
+	cmdEvents.Flags().Int64Var(&eventsBlock, "block", 0, "block number")
+	cmdEvents.Flags().StringVar(&abiFile, "abi", "", "abi file used to decode events")
+
+	cmdDecodeInput := &cobra.Command{
+		Use:   "decode-input",
+		Short: "decode contract call data",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if abiFile == "" {
+				return fmt.Errorf("no abi file specified")
+			}
+			ABI, err := loadABIFile(abiFile)
+			if err != nil {
+				return err
+			}
+			data, err := common.FromHex(inputData)
+			if err != nil {
+				return fmt.Errorf("invalid data: %v", err)
+			}
+			method, params, err := abi.DecodeInput(ABI, data)
+			if err != nil {
+				return err
+			}
+
+			result := make(map[string]interface{})
+			result["method"] = method
+			result["params"] = params
+
+			asJSON, _ := json.Marshal(result)
+			if noPrettyOutput {
+				fmt.Println(string(asJSON))
+				return nil
+			}
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdDecodeInput.Flags().StringVar(&abiFile, "abi", "", "abi file location")
+	cmdDecodeInput.Flags().StringVar(&inputData, "data", "", "call data HEX string")
+
+	cmdSelfDestruct := &cobra.Command{
+		Use:   "self-destruct <CONTRACT_ADDRESS>",
+		Short: "call the contract self destruct method, sending its balance to a beneficiary",
+		Long:  "The contract must expose an owner only method executing SELFDESTRUCT, set with --method",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			contractAddress, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			if destructBeneficiary == "" {
+				return fmt.Errorf("no beneficiary specified")
+			}
+			beneficiary, err := findAddress(destructBeneficiary)
+			if err != nil {
+				return err
+			}
+
+			ok, err := confirmPrompt(fmt.Sprintf("Self destruct %s sending its balance to %s?",
+				contractAddress.String(), beneficiary.String()))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("aborted")
+			}
+			fmt.Print("This can not be undone, type the contract address to confirm: ")
+			typed, err := stdin.ReadString('\n')
+			if err != nil && len(typed) == 0 {
+				return err
+			}
+			if strings.TrimSpace(typed) != contractAddress.String() {
+				return fmt.Errorf("aborted: contract address does not match")
+			}
+
+			tx, err := conn.SuicideContractTo(signerAddress.String(), contractAddress.String(),
+				destructMethod, beneficiary.String(), destructFeeLimit)
+			if err != nil {
+				return err
+			}
+
+			var ctrlr *transaction.Controller
+			if useLedgerWallet {
+				account := keystore.Account{Address: signerAddress.GetAddress()}
+				ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+				if err != nil {
+					return err
+				}
+				ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+			}
+			if err = ctrlr.ExecuteTransaction(); err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(tx)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["txID"], _ = ctrlr.TransactionHash()
+			result["contract"] = contractAddress.String()
+			result["beneficiary"] = beneficiary.String()
+			result["blockNumber"] = ctrlr.Receipt.BlockNumber
+			result["message"] = string(ctrlr.Result.Message)
+			result["success"] = ctrlr.GetResultError() == nil
+			result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdSelfDestruct.Flags().StringVar(&destructBeneficiary, "beneficiary", "", "address receiving the contract balance")
+	cmdSelfDestruct.Flags().StringVar(&destructMethod, "method", client.DefaultSelfDestructMethod, "contract method executing SELFDESTRUCT")
+	cmdSelfDestruct.Flags().Int64Var(&destructFeeLimit, "feeLimit", 100000000, "fee limit")
+
+	cmdBytecode := &cobra.Command{
+		Use:   "bytecode <CONTRACT_ADDRESS>",
+		Short: "get the runtime bytecode deployed at a contract address",
+		Long:  "Prints the bytecode as HEX, or writes it as binary to --out unless --hex is set",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contractAddress, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			code, err := conn.GetSmartContractByteCode(contractAddress.String())
+			if err != nil {
+				return err
+			}
+			if bytecodeOut == "" {
+				fmt.Println(common.BytesToHexString(code))
+				return nil
+			}
+			if bytecodeHex {
+				code = []byte(common.BytesToHexString(code))
+			}
+			if err = ioutil.WriteFile(bytecodeOut, code, 0644); err != nil {
+				return err
+			}
+			fmt.Printf("%d bytes written to %s\n", len(code), bytecodeOut)
+			return nil
+		},
+	}
+	cmdBytecode.Flags().StringVar(&bytecodeOut, "out", "", "file to write the bytecode to")
+	cmdBytecode.Flags().BoolVar(&bytecodeHex, "hex", false, "write HEX instead of binary to --out")
+
+	cmdCodeHash := &cobra.Command{
+		Use:   "code-hash <ADDRESS>",
+		Short: "get the Keccak-256 hash of the runtime bytecode deployed at an address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contractAddress, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			hash, err := conn.GetAccountCodeHash(contractAddress.String())
+			if err != nil && !errors.Is(err, client.ErrNotAContract) {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(hash)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["address"] = contractAddress.String()
+			result["codeHash"] = hash
+			result["isContract"] = err == nil
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	cmdUpgrade := &cobra.Command{
+		Use:   "upgrade <PROXY_ADDRESS> <IMPLEMENTATION_ADDRESS>",
+		Short: "point an EIP-1967 proxy to a new implementation",
+		Long: "Calls upgradeTo, or upgradeToAndCall with --init-data, signed by the proxy admin.\n" +
+			"The implementation is read before and after through implementation(), answered to the admin;\n" +
+			"proxies without it, e.g. UUPS, are refused as unsupported.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			proxy, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			implementation, err := findAddress(args[1])
+			if err != nil {
+				return err
+			}
+			var initData []byte
+			if upgradeInitData != "" {
+				if initData, err = common.FromHex(upgradeInitData); err != nil {
+					return fmt.Errorf("invalid init data: %v", err)
+				}
+			}
+
+			previous, err := conn.GetProxyImplementation(signerAddress.String(), proxy.String())
+			if err != nil {
+				return err
+			}
+			if previous == implementation.String() {
+				return fmt.Errorf("%s already points to %s", proxy.String(), previous)
+			}
+			ok, err := confirmPrompt(fmt.Sprintf("Upgrade %s from %s to %s?",
+				proxy.String(), previous, implementation.String()))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("aborted")
+			}
+
+			tx, err := conn.UpgradeProxy(signerAddress.String(), proxy.String(), implementation.String(), initData, upgradeFeeLimit)
+			if err != nil {
+				return err
+			}
+
+			var ctrlr *transaction.Controller
+			if useLedgerWallet {
+				account := keystore.Account{Address: signerAddress.GetAddress()}
+				ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+				if err != nil {
+					return err
+				}
+				ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+			}
+			if err = ctrlr.ExecuteTransaction(); err != nil {
+				return err
+			}
+
+			current, err := conn.GetProxyImplementation(signerAddress.String(), proxy.String())
+			if err != nil {
+				txID, _ := ctrlr.TransactionHash()
+				return fmt.Errorf("upgrade %s sent, implementation not verified: %v", txID, err)
+			}
+
+			if noPrettyOutput {
+				fmt.Println(tx)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["txID"], _ = ctrlr.TransactionHash()
+			result["proxy"] = proxy.String()
+			result["previousImplementation"] = previous
+			result["implementation"] = current
+			result["verified"] = current == implementation.String()
+			result["blockNumber"] = ctrlr.Receipt.BlockNumber
+			result["success"] = ctrlr.GetResultError() == nil
+			result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdUpgrade.Flags().StringVar(&upgradeInitData, "init-data", "", "HEX call data run on the new implementation, uses upgradeToAndCall")
+	cmdUpgrade.Flags().Int64Var(&upgradeFeeLimit, "feeLimit", 100000000, "fee limit")
+
+	return []*cobra.Command{cmdDeploy, cmdConstant, cmdTrigger, cmdEvents, cmdDecodeInput, cmdSelfDestruct, cmdBytecode, cmdCodeHash, cmdUpgrade}
 }
 
 func init() {
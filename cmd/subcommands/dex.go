@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract/sunswap"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	swapSlippage string
+	swapRouter   string
+	swapDeadline int64
+	dexFeeLimit  int64
+)
+
+// parseSlippage converts "1%" or "0.5" into basis points
+func parseSlippage(value string) (int64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid slippage %s", value)
+	}
+	bps := int64(math.Round(f * 100))
+	if bps < 0 || bps >= 10000 {
+		return 0, fmt.Errorf("invalid slippage %s: must be between 0%% and 100%%", value)
+	}
+	return bps, nil
+}
+
+func dexSub() []*cobra.Command {
+	cmdSwap := &cobra.Command{
+		Use:   "swap <FROM_TOKEN> <TO_TOKEN> <AMOUNT>",
+		Short: "swap TRC20 tokens through SunSwap V2",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			fromToken, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			toToken, err := findAddress(args[1])
+			if err != nil {
+				return err
+			}
+			bps, err := parseSlippage(swapSlippage)
+			if err != nil {
+				return err
+			}
+			tokenDecimals, err := conn.TRC20GetDecimals(fromToken.String())
+			if err != nil {
+				return fmt.Errorf("get decimals of %s: %v", fromToken.String(), err)
+			}
+			amountIn, err := common.ParseAmount(args[2], int(tokenDecimals.Int64()))
+			if err != nil {
+				return err
+			}
+
+			router := sunswap.NewRouter(conn, swapRouter)
+			router.FeeLimit = dexFeeLimit
+			path := []string{fromToken.String(), toToken.String()}
+			expectedOut, err := router.GetAmountOut(amountIn, path)
+			if err != nil {
+				return err
+			}
+			minOut := new(big.Int).Mul(expectedOut, big.NewInt(10000-bps))
+			minOut.Div(minOut, big.NewInt(10000))
+
+			// sign with passphrase as approve and swap may both be signed
+			var ks *keystore.KeyStore
+			var acct *keystore.Account
+			if useLedgerWallet {
+				acct = &keystore.Account{Address: signerAddress.GetAddress()}
+			} else {
+				ks, acct, err = store.UnlockedKeystore(signerAddress.String(), passphrase)
+				if err != nil {
+					return err
+				}
+			}
+			swapOpts := []func(*transaction.Controller){opts}
+			if !useLedgerWallet {
+				swapOpts = append(swapOpts, transaction.WithPassphrase(passphrase))
+			}
+
+			result := make(map[string]interface{})
+			allowance, err := conn.TRC20Allowance(signerAddress.String(), swapRouter, fromToken.String())
+			if err != nil {
+				return err
+			}
+			if allowance.Cmp(amountIn) < 0 {
+				tx, err := conn.TRC20Approve(signerAddress.String(), swapRouter, fromToken.String(), amountIn, dexFeeLimit)
+				if err != nil {
+					return err
+				}
+				// swap must only be sent once approval is confirmed
+				approveOpts := append(swapOpts, func(c *transaction.Controller) {
+					if c.Behavior.ConfirmationWaitTime == 0 {
+						c.Behavior.ConfirmationWaitTime = 60
+					}
+				})
+				ctrlr := transaction.NewController(conn, ks, acct, tx.Transaction, approveOpts...)
+				if err = ctrlr.ExecuteTransaction(); err != nil {
+					return err
+				}
+				if err = ctrlr.GetResultError(); err != nil {
+					return fmt.Errorf("approve failed: %v", err)
+				}
+				result["approveTxID"], _ = ctrlr.TransactionHash()
+			}
+
+			deadline := time.Now().Add(time.Duration(swapDeadline) * time.Second).Unix()
+			tx, err := router.SwapExactTokensForTokens(signerAddress.String(), amountIn, minOut,
+				path, signerAddress.String(), deadline)
+			if err != nil {
+				return err
+			}
+			ctrlr := transaction.NewController(conn, ks, acct, tx.Transaction, swapOpts...)
+			if err = ctrlr.ExecuteTransaction(); err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(tx)
+				return nil
+			}
+
+			result["txID"], _ = ctrlr.TransactionHash()
+			result["blockNumber"] = ctrlr.Receipt.BlockNumber
+			result["amountIn"] = amountIn.String()
+			result["expectedOut"] = expectedOut.String()
+			result["minOut"] = minOut.String()
+			result["success"] = ctrlr.GetResultError() == nil
+			result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+			result["receipt"] = map[string]interface{}{
+				"fee":              ctrlr.Receipt.Fee,
+				"energyFee":        ctrlr.Receipt.Receipt.EnergyFee,
+				"energyUsageTotal": ctrlr.Receipt.Receipt.EnergyUsageTotal,
+				"netFee":           ctrlr.Receipt.Receipt.NetFee,
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdSwap.Flags().StringVar(&swapSlippage, "slippage", "1%", "maximum accepted slippage")
+	cmdSwap.Flags().StringVar(&swapRouter, "router", sunswap.RouterAddress, "SunSwap V2 router address")
+	cmdSwap.Flags().Int64Var(&swapDeadline, "deadline", 600, "seconds until the swap expires")
+	cmdSwap.Flags().Int64Var(&dexFeeLimit, "feeLimit", 100000000, "fee limit")
+
+	return []*cobra.Command{cmdSwap}
+}
+
+func init() {
+	cmdDex := &cobra.Command{
+		Use:   "dex",
+		Short: "Decentralized exchange actions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdDex.AddCommand(dexSub()...)
+	RootCmd.AddCommand(cmdDex)
+}
@@ -3,7 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"math"
+	"math/big"
 	"strconv"
 	"time"
 
@@ -16,7 +16,7 @@ import (
 )
 
 var (
-	expectedAmount float64
+	expectedAmount string
 )
 
 func exchangeSub() []*cobra.Command {
@@ -29,57 +29,24 @@ func exchangeSub() []*cobra.Command {
 				return fmt.Errorf("no signer specified")
 			}
 
-			tokenID1 := args[0]
-			// get amount
-			tokenValue1, err := strconv.ParseFloat(args[1], 64)
+			if exchangeTokenID(args[0]) == exchangeTokenID(args[2]) {
+				return fmt.Errorf("token ID cannot be the same")
+			}
+			tokenID1, tokenValue1, err := exchangeTokenAmount(args[0], args[1])
 			if err != nil {
 				return err
 			}
-			tokenID2 := args[2]
-			// get amount
-			tokenValue2, err := strconv.ParseFloat(args[3], 64)
+			tokenID2, tokenValue2, err := exchangeTokenAmount(args[2], args[3])
 			if err != nil {
 				return err
 			}
-			if tokenID1 == tokenID2 {
-				return fmt.Errorf("token ID cannot be the same")
-			}
-			if tokenValue1 <= 0 || tokenValue2 <= 0 {
-				return fmt.Errorf("invalid token amount")
-			}
-
-			if tokenID1 == "TRX" || tokenID1 == "0" {
-				tokenID1 = "_"
-				tokenValue1 = tokenValue1 * math.Pow10(6)
-			}
-			if tokenID2 == "TRX" || tokenID2 == "0" {
-				tokenID2 = "_"
-				tokenValue2 = tokenValue2 * math.Pow10(6)
-			}
-
-			// Get asset information
-			// check if possible id
-			if tokenID1 != "_" {
-				if asset, err := conn.GetAssetIssueByID(tokenID1); err == nil {
-					tokenValue1 = tokenValue1 * math.Pow10(int(asset.Precision))
-				} else {
-					return fmt.Errorf("TRC10 not found: %s", tokenID1)
-				}
-			}
-			if tokenID2 != "_" {
-				if asset, err := conn.GetAssetIssueByID(tokenID2); err == nil {
-					tokenValue2 = tokenValue2 * math.Pow10(int(asset.Precision))
-				} else {
-					return fmt.Errorf("TRC10 not found: %s", tokenID2)
-				}
-			}
 
 			tx, err := conn.ExchangeCreate(
 				signerAddress.String(),
 				tokenID1,
-				int64(tokenValue1),
+				tokenValue1,
 				tokenID2,
-				int64(tokenValue2),
+				tokenValue2,
 			)
 			if err != nil {
 				return err
@@ -106,7 +73,7 @@ func exchangeSub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -135,35 +102,16 @@ func exchangeSub() []*cobra.Command {
 				return err
 			}
 
-			tokenID1 := args[1]
-			// get amount
-			tokenValue1, err := strconv.ParseFloat(args[2], 64)
+			tokenID1, tokenValue1, err := exchangeTokenAmount(args[1], args[2])
 			if err != nil {
 				return err
 			}
-			if tokenValue1 <= 0 {
-				return fmt.Errorf("invalid token amount")
-			}
-
-			if tokenID1 == "TRX" || tokenID1 == "0" {
-				tokenID1 = "_"
-				tokenValue1 = tokenValue1 * math.Pow10(6)
-			}
-			// Get asset information
-			// check if possible id
-			if tokenID1 != "_" {
-				if asset, err := conn.GetAssetIssueByID(tokenID1); err == nil {
-					tokenValue1 = tokenValue1 * math.Pow10(int(asset.Precision))
-				} else {
-					return fmt.Errorf("TRC10 not found: %s", tokenID1)
-				}
-			}
 
 			tx, err := conn.ExchangeInject(
 				signerAddress.String(),
 				exchangeID,
 				tokenID1,
-				int64(tokenValue1),
+				tokenValue1,
 			)
 			if err != nil {
 				return err
@@ -190,14 +138,14 @@ func exchangeSub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
 				"fee":          ctrlr.Receipt.Fee,
 				"netFee":       ctrlr.Receipt.Receipt.NetFee,
 				"netUsage":     ctrlr.Receipt.Receipt.NetUsage,
-				"TokenAmount1": int64(tokenValue1),
+				"TokenAmount1": tokenValue1,
 				"TokenAmount2": ctrlr.Receipt.ExchangeInjectAnotherAmount,
 			}
 
@@ -221,35 +169,16 @@ func exchangeSub() []*cobra.Command {
 				return err
 			}
 
-			tokenID1 := args[1]
-			// get amount
-			tokenValue1, err := strconv.ParseFloat(args[2], 64)
+			tokenID1, tokenValue1, err := exchangeTokenAmount(args[1], args[2])
 			if err != nil {
 				return err
 			}
-			if tokenValue1 <= 0 {
-				return fmt.Errorf("invalid token amount")
-			}
-
-			if tokenID1 == "TRX" || tokenID1 == "0" {
-				tokenID1 = "_"
-				tokenValue1 = tokenValue1 * math.Pow10(6)
-			}
-			// Get asset information
-			// check if possible id
-			if tokenID1 != "_" {
-				if asset, err := conn.GetAssetIssueByID(tokenID1); err == nil {
-					tokenValue1 = tokenValue1 * math.Pow10(int(asset.Precision))
-				} else {
-					return fmt.Errorf("TRC10 not found: %s", tokenID1)
-				}
-			}
 
 			tx, err := conn.ExchangeWithdraw(
 				signerAddress.String(),
 				exchangeID,
 				tokenID1,
-				int64(tokenValue1),
+				tokenValue1,
 			)
 			if err != nil {
 				return err
@@ -276,14 +205,14 @@ func exchangeSub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
 				"fee":          ctrlr.Receipt.Fee,
 				"netFee":       ctrlr.Receipt.Receipt.NetFee,
 				"netUsage":     ctrlr.Receipt.Receipt.NetUsage,
-				"TokenAmount1": int64(tokenValue1),
+				"TokenAmount1": tokenValue1,
 				"TokenAmount2": ctrlr.Receipt.ExchangeWithdrawAnotherAmount,
 			}
 
@@ -345,36 +274,18 @@ func exchangeSub() []*cobra.Command {
 				return err
 			}
 
-			tokenID1 := args[1]
-			// get amount
-			tokenValue1, err := strconv.ParseFloat(args[2], 64)
+			tokenID1, tokenValue1, err := exchangeTokenAmount(args[1], args[2])
 			if err != nil {
 				return err
 			}
-			if tokenValue1 <= 0 {
-				return fmt.Errorf("invalid token amount")
-			}
-
-			if tokenID1 == "TRX" || tokenID1 == "0" {
-				tokenID1 = "_"
-				tokenValue1 = tokenValue1 * math.Pow10(6)
-			}
-			// Get asset information
-			// check if possible id
-			if tokenID1 != "_" {
-				if asset, err := conn.GetAssetIssueByID(tokenID1); err == nil {
-					tokenValue1 = tokenValue1 * math.Pow10(int(asset.Precision))
-				} else {
-					return fmt.Errorf("TRC10 not found: %s", tokenID1)
-				}
-			}
 
 			// compute expected amount
+			var expected int64
 			if e, err := conn.ExchangeByID(exchangeID); err == nil {
 				tokenDecimal := 6
 				T1 := string(e.FirstTokenId)
 				T2 := string(e.SecondTokenId)
-				ratio := float64(0)
+				var balanceIn, balanceOut int64
 				switch tokenID1 {
 				case T1:
 					if T2 != "_" {
@@ -383,7 +294,7 @@ func exchangeSub() []*cobra.Command {
 							tokenDecimal = int(asset.Precision)
 						}
 					}
-					ratio = (float64(e.FirstTokenBalance) + tokenValue1) / float64(e.SecondTokenBalance)
+					balanceIn, balanceOut = e.FirstTokenBalance, e.SecondTokenBalance
 				case T2:
 					if T1 != "_" {
 						// get other token decimals
@@ -391,14 +302,16 @@ func exchangeSub() []*cobra.Command {
 							tokenDecimal = int(asset.Precision)
 						}
 					}
-					ratio = (float64(e.SecondTokenBalance) + tokenValue1) / float64(e.FirstTokenBalance)
+					balanceIn, balanceOut = e.SecondTokenBalance, e.FirstTokenBalance
 				default:
 					return fmt.Errorf("Token ID provided does not match excahnge %s/%s", T1, T2)
 				}
-				if expectedAmount != 0 {
-					expectedAmount = expectedAmount * math.Pow10(tokenDecimal)
+				if expectedAmount != "" {
+					if expected, err = common.ParseAmountInt64(expectedAmount, tokenDecimal); err != nil {
+						return err
+					}
 				} else {
-					expectedAmount = math.Floor(tokenValue1/ratio + 0.5)
+					expected = exchangeExpectedAmount(tokenValue1, balanceIn, balanceOut)
 				}
 			} else {
 				return fmt.Errorf("Cannot fetch echange info: %+v", err)
@@ -408,8 +321,8 @@ func exchangeSub() []*cobra.Command {
 				signerAddress.String(),
 				exchangeID,
 				tokenID1,
-				int64(tokenValue1),
-				int64(expectedAmount),
+				tokenValue1,
+				expected,
 			)
 			if err != nil {
 				return err
@@ -436,16 +349,16 @@ func exchangeSub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
 				"fee":           ctrlr.Receipt.Fee,
 				"netFee":        ctrlr.Receipt.Receipt.NetFee,
 				"netUsage":      ctrlr.Receipt.Receipt.NetUsage,
-				"TokenAmount1":  int64(tokenValue1),
+				"TokenAmount1":  tokenValue1,
 				"TokenAmount2":  ctrlr.Receipt.ExchangeReceivedAmount,
-				"TokenExpected": int64(expectedAmount),
+				"TokenExpected": expected,
 			}
 
 			asJSON, _ := json.Marshal(result)
@@ -453,7 +366,7 @@ func exchangeSub() []*cobra.Command {
 			return nil
 		},
 	}
-	cmdTrade.Flags().Float64VarP(&expectedAmount, "expected", "x", 0, "especify expected amount in return")
+	cmdTrade.Flags().StringVarP(&expectedAmount, "expected", "x", "", "especify expected amount in return")
 
 	return []*cobra.Command{cmdCreate, cmdInject, cmdWithdraw, cmdList, cmdTrade}
 }
@@ -471,3 +384,45 @@ func init() {
 	cmdExchange.AddCommand(exchangeSub()...)
 	RootCmd.AddCommand(cmdExchange)
 }
+
+// exchangeTokenID exchange ID of a token, "_" for TRX
+func exchangeTokenID(tokenID string) string {
+	if tokenID == "TRX" || tokenID == "0" {
+		return "_"
+	}
+	return tokenID
+}
+
+// exchangeTokenAmount resolves tokenID and converts amount to its base units,
+// SUN for TRX or the TRC10 precision
+func exchangeTokenAmount(tokenID, amount string) (string, int64, error) {
+	tokenID = exchangeTokenID(tokenID)
+	decimals := common.AmountDecimalPoint
+	if tokenID != "_" {
+		asset, err := conn.GetAssetIssueByID(tokenID)
+		if err != nil {
+			return "", 0, fmt.Errorf("TRC10 not found: %s", tokenID)
+		}
+		decimals = int(asset.Precision)
+	}
+	value, err := common.ParseAmountInt64(amount, decimals)
+	if err != nil {
+		return "", 0, err
+	}
+	if value <= 0 {
+		return "", 0, fmt.Errorf("invalid token amount")
+	}
+	return tokenID, value, nil
+}
+
+// exchangeExpectedAmount bancor output of selling amount to a pool holding
+// balanceIn and balanceOut, rounded to the nearest unit
+func exchangeExpectedAmount(amount, balanceIn, balanceOut int64) int64 {
+	den := new(big.Int).Add(big.NewInt(balanceIn), big.NewInt(amount))
+	if den.Sign() <= 0 {
+		return 0
+	}
+	num := new(big.Int).Mul(big.NewInt(amount), big.NewInt(balanceOut))
+	num.Add(num.Mul(num, big.NewInt(2)), den)
+	return num.Div(num, den.Mul(den, big.NewInt(2))).Int64()
+}
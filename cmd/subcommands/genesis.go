@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/spf13/cobra"
+)
+
+var genesisOutput string
+
+// genesisAsset account funded by the genesis block
+type genesisAsset struct {
+	Address        string `json:"address"`
+	Balance        int64  `json:"balance"`
+	CurrentBalance int64  `json:"currentBalance"`
+}
+
+// genesisWitness super representative, in the genesis.block witnesses format
+type genesisWitness struct {
+	Address   string `json:"address"`
+	URL       string `json:"url"`
+	VoteCount int64  `json:"voteCount"`
+}
+
+func genesisSub() []*cobra.Command {
+	cmdExport := &cobra.Command{
+		Use:   "export",
+		Short: "export the node genesis accounts, witnesses and chain parameters as JSON",
+		Long: "Builds a genesis.block like document for local java-tron networks: accounts funded by block 0\n" +
+			"with their current balance, the current witnesses and chain parameters",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := conn.GetNodeInfo()
+			if err != nil {
+				return err
+			}
+			block, err := conn.GetBlockByNum(0)
+			if err != nil {
+				return err
+			}
+			witnesses, err := conn.ListWitnesses()
+			if err != nil {
+				return err
+			}
+			params, err := conn.ChainParametersSnapshot()
+			if err != nil {
+				return err
+			}
+
+			assets := make([]genesisAsset, 0)
+			for _, tx := range block.GetTransactions() {
+				for _, contract := range tx.GetTransaction().GetRawData().GetContract() {
+					if contract.GetType() != core.Transaction_Contract_TransferContract {
+						continue
+					}
+					transfer := &core.TransferContract{}
+					if err = contract.GetParameter().UnmarshalTo(transfer); err != nil {
+						return err
+					}
+					asset := genesisAsset{
+						Address: address.Address(transfer.GetToAddress()).String(),
+						Balance: transfer.GetAmount(),
+					}
+					acc, err := conn.GetAccount(asset.Address)
+					if err != nil {
+						return fmt.Errorf("account %s: %v", asset.Address, err)
+					}
+					asset.CurrentBalance = acc.GetBalance()
+					assets = append(assets, asset)
+				}
+			}
+
+			srs := make([]genesisWitness, 0, len(witnesses.GetWitnesses()))
+			for _, w := range witnesses.GetWitnesses() {
+				srs = append(srs, genesisWitness{
+					Address:   address.Address(w.GetAddress()).String(),
+					URL:       w.GetUrl(),
+					VoteCount: w.GetVoteCount(),
+				})
+			}
+
+			header := block.GetBlockHeader().GetRawData()
+			result := make(map[string]interface{})
+			result["node"] = conn.Address
+			result["p2pVersion"] = info.GetConfigNodeInfo().GetP2PVersion()
+			result["codeVersion"] = info.GetConfigNodeInfo().GetCodeVersion()
+			result["timestamp"] = header.GetTimestamp()
+			result["parentHash"] = common.BytesToHexString(header.GetParentHash())
+			result["blockID"] = common.BytesToHexString(block.GetBlockid())
+			result["assets"] = assets
+			result["witnesses"] = srs
+			result["chainParameters"] = params
+
+			asJSON, _ := json.Marshal(result)
+			out := string(asJSON)
+			if !noPrettyOutput {
+				out = common.JSONPrettyFormat(out)
+			}
+			if genesisOutput == "" {
+				fmt.Println(out)
+				return nil
+			}
+			if err = ioutil.WriteFile(genesisOutput, []byte(out+"\n"), 0644); err != nil {
+				return err
+			}
+			fmt.Printf("genesis of %s written to %s\n", conn.Address, genesisOutput)
+			return nil
+		},
+	}
+	cmdExport.Flags().StringVar(&genesisOutput, "output", "", "file to write the JSON to, stdout by default")
+
+	return []*cobra.Command{cmdExport}
+}
+
+func init() {
+	cmdGenesis := &cobra.Command{
+		Use:   "genesis",
+		Short: "Genesis configuration tools",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdGenesis.AddCommand(genesisSub()...)
+	RootCmd.AddCommand(cmdGenesis)
+}
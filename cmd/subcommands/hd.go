@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys/hd"
+	"github.com/fbsobreira/gotron-sdk/pkg/store"
+	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// tronCoinType SLIP-44 coin type used in TRON derivation paths
+const tronCoinType = 195
+
+var (
+	hdMnemonicFile       string
+	hdMnemonicPassphrase string
+	hdCount              uint32
+	hdIndex              uint32
+	hdName               string
+)
+
+// readMnemonicFile loads and validates the mnemonic, and its optional
+// passphrase, from files so neither ends up in the shell history
+func readMnemonicFile() (string, string, error) {
+	if hdMnemonicFile == "" {
+		return "", "", fmt.Errorf("--mnemonic-file is required")
+	}
+	content, err := ioutil.ReadFile(hdMnemonicFile)
+	if err != nil {
+		return "", "", err
+	}
+	m := strings.Join(strings.Fields(string(content)), " ")
+	if !bip39.IsMnemonicValid(m) {
+		return "", "", fmt.Errorf("invalid mnemonic given")
+	}
+	p := ""
+	if hdMnemonicPassphrase != "" {
+		content, err = ioutil.ReadFile(hdMnemonicPassphrase)
+		if err != nil {
+			return "", "", err
+		}
+		p = strings.TrimRight(string(content), "\r\n")
+	}
+	return m, p, nil
+}
+
+func hdPath(index uint32) string {
+	return "m/" + hd.NewFundraiserParams(0, tronCoinType, index).String()
+}
+
+func hdSub() []*cobra.Command {
+	cmdDerive := &cobra.Command{
+		Use:   "derive",
+		Short: "Validate a mnemonic and list the first derived addresses",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, p, err := readMnemonicFile()
+			if err != nil {
+				return err
+			}
+			if hdCount == 0 {
+				return fmt.Errorf("invalid count: %d", hdCount)
+			}
+
+			result := make([]map[string]interface{}, 0, hdCount)
+			for i := uint32(0); i < hdCount; i++ {
+				_, pub := keys.FromMnemonicSeedAndPassphrase(m, p, int(i))
+				addr := address.PubkeyToAddress(*pub.ToECDSA()).String()
+				if noPrettyOutput {
+					fmt.Printf("%d\t%s\t%s\n", i, hdPath(i), addr)
+					continue
+				}
+				result = append(result, map[string]interface{}{
+					"index":   i,
+					"path":    hdPath(i),
+					"address": addr,
+				})
+			}
+			if noPrettyOutput {
+				return nil
+			}
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdDerive.Flags().Uint32Var(&hdCount, "count", 10, "number of addresses to derive")
+
+	cmdImport := &cobra.Command{
+		Use:   "import",
+		Short: "Store the account derived at the given index in the keystore",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if hdName == "" {
+				return fmt.Errorf("--name is required")
+			}
+			if store.DoesNamedAccountExist(hdName) {
+				return fmt.Errorf("account %s already exists", hdName)
+			}
+			m, p, err := readMnemonicFile()
+			if err != nil {
+				return err
+			}
+			passphrase, err := getPassphraseWithConfirm()
+			if err != nil {
+				return err
+			}
+
+			private, _ := keys.FromMnemonicSeedAndPassphrase(m, p, int(hdIndex))
+			ks := store.FromAccountName(hdName)
+			if _, err = ks.ImportECDSA(private.ToECDSA(), passphrase); err != nil {
+				return err
+			}
+			fmt.Printf("Imported %s as account `%s`\n", hdPath(hdIndex), hdName)
+			addr, _ := store.AddressFromAccountName(hdName)
+			fmt.Printf("Tron Address: %s\n", addr)
+			return nil
+		},
+	}
+	cmdImport.Flags().Uint32Var(&hdIndex, "index", 0, "address index in the derivation path")
+	cmdImport.Flags().StringVar(&hdName, "name", "", "name of the new account")
+
+	for _, cmd := range []*cobra.Command{cmdDerive, cmdImport} {
+		cmd.Flags().StringVar(&hdMnemonicFile, "mnemonic-file", "", "file holding the mnemonic")
+		cmd.Flags().StringVar(&hdMnemonicPassphrase, "mnemonic-passphrase-file", "", "file holding the mnemonic passphrase [optional]")
+	}
+
+	return []*cobra.Command{cmdDerive, cmdImport}
+}
+
+func init() {
+	cmdHD := &cobra.Command{
+		Use:   "hd",
+		Short: "HD wallet (BIP-44) tools",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdHD.AddCommand(hdSub()...)
+	RootCmd.AddCommand(cmdHD)
+}
@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/csv"
 	"encoding/hex"
 	"fmt"
 	"os"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/fatih/color"
@@ -21,6 +25,9 @@ import (
 	"github.com/tyler-smith/go-bip39"
 )
 
+// mnemonicDisplayTime how long show-mnemonic keeps the mnemonic on screen
+const mnemonicDisplayTime = 30 * time.Second
+
 const (
 	seedPhraseWarning = "**Important** write this seed phrase in a safe place, " +
 		"it is the only way to recover your account if you ever forget your password\n\n"
@@ -29,6 +36,11 @@ const (
 var (
 	quietImport         bool
 	recoverFromMnemonic bool
+	storeMnemonic       bool
+	confirmDangerous    bool
+	batchCount          int
+	batchOutput         string
+	batchImport         bool
 	passphrase          string
 	ppPrompt            = fmt.Sprintf(
 		"prompt for passphrase, otherwise use default passphrase: \"`%s`\"", c.DefaultPassphrase,
@@ -71,8 +83,9 @@ func keysSub() []*cobra.Command {
 				return err
 			}
 			acc := account.Creation{
-				Name:       args[0],
-				Passphrase: passphrase,
+				Name:          args[0],
+				Passphrase:    passphrase,
+				StoreMnemonic: storeMnemonic,
 			}
 
 			if err := account.CreateNewLocalAccount(&acc); err != nil {
@@ -122,8 +135,9 @@ func keysSub() []*cobra.Command {
 				return err
 			}
 			acc := account.Creation{
-				Name:       args[0],
-				Passphrase: passphrase,
+				Name:          args[0],
+				Passphrase:    passphrase,
+				StoreMnemonic: storeMnemonic,
 			}
 			fmt.Println("Enter mnemonic to recover keys from")
 			scanner := bufio.NewScanner(os.Stdin)
@@ -172,6 +186,8 @@ func keysSub() []*cobra.Command {
 			return err
 		},
 	}
+	cmdAdd.Flags().BoolVar(&storeMnemonic, "store-mnemonic", false, "keep the mnemonic, encrypted, for show-mnemonic")
+	cmdRecoverMnemonic.Flags().BoolVar(&storeMnemonic, "store-mnemonic", false, "keep the mnemonic, encrypted, for show-mnemonic")
 	cmdImportKS.Flags().BoolVar(&quietImport, "quiet", false, "do not print out imported account name")
 
 	cmdImportPK := &cobra.Command{
@@ -198,6 +214,39 @@ func keysSub() []*cobra.Command {
 	}
 	cmdImportPK.Flags().BoolVar(&quietImport, "quiet", false, "do not print out imported account name")
 
+	cmdImportHex := &cobra.Command{
+		Use:   "import-hex <HEX_PRIVATE_KEY> [ACCOUNT_NAME]",
+		Short: "Import a raw 32 byte HEX secp256k1 private key, named after its address by default",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) == 2 {
+				name = args[1]
+			} else {
+				keyBytes, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+				if err != nil || len(keyBytes) != c.Secp256k1PrivateKeyBytesLength {
+					return c.ErrBadKeyLength
+				}
+				sk, _ := btcec.PrivKeyFromBytes(keyBytes)
+				name = address.PubkeyToAddress(sk.ToECDSA().PublicKey).String()
+			}
+			passphrase, err := getPassphraseWithConfirm()
+			if err != nil {
+				return err
+			}
+			if err = store.ImportHexPrivKey(name, args[0], passphrase); err != nil {
+				return err
+			}
+			if !quietImport {
+				fmt.Printf("Imported private key given account alias of `%s`\n", name)
+				addr, _ := store.AddressFromAccountName(name)
+				fmt.Printf("Tron Address: %s\n", addr)
+			}
+			return nil
+		},
+	}
+	cmdImportHex.Flags().BoolVar(&quietImport, "quiet", false, "do not print out imported account name")
+
 	cmdExportPK := &cobra.Command{
 		Use:     "export-private-key <ACCOUNT_ADDRESS>",
 		Short:   "Export the secp256k1 private key",
@@ -212,6 +261,51 @@ func keysSub() []*cobra.Command {
 		},
 	}
 
+	cmdShowMnemonic := &cobra.Command{
+		Use:   "show-mnemonic <ACCOUNT_NAME>",
+		Short: "Display the stored mnemonic on the terminal, cleared after 30 seconds",
+		Long: "The mnemonic is written to the controlling terminal, never to stdout, " +
+			"so it does not end up in pipes or logs. Only accounts created with --store-mnemonic " +
+			"have one. Requires --confirm-dangerous",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !confirmDangerous {
+				return fmt.Errorf("anyone seeing the mnemonic controls the account, pass --confirm-dangerous to continue")
+			}
+			if !store.DoesNamedAccountExist(args[0]) {
+				return fmt.Errorf("account %s doesn't exist", args[0])
+			}
+			tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+			if err != nil {
+				return fmt.Errorf("a terminal is required to show the mnemonic: %v", err)
+			}
+			defer tty.Close()
+
+			if passphraseFilePath == "" {
+				userProvidesPassphrase = true
+			}
+			passphrase, err := getPassphrase()
+			if err != nil {
+				return err
+			}
+			words, err := store.ExportMnemonic(args[0], passphrase)
+			if err != nil {
+				return err
+			}
+
+			// save the cursor position to erase everything written below it
+			fmt.Fprintf(tty, "\0337%s\n", words)
+			for left := mnemonicDisplayTime; left > 0; left -= time.Second {
+				fmt.Fprintf(tty, "\rclearing in %2ds", int(left.Seconds()))
+				time.Sleep(time.Second)
+			}
+			// back to the saved position and erase the mnemonic and countdown
+			fmt.Fprint(tty, "\0338\033[J")
+			return nil
+		},
+	}
+	cmdShowMnemonic.Flags().BoolVar(&confirmDangerous, "confirm-dangerous", false, "confirm the mnemonic may be displayed")
+
 	cmdExportKS := &cobra.Command{
 		Use:     "export-ks <ACCOUNT_ADDRESS> <OUTPUT_DIRECTORY>",
 		Short:   "Export the keystore file contents",
@@ -230,6 +324,59 @@ func keysSub() []*cobra.Command {
 		},
 	}
 
+	cmdGenerateBatch := &cobra.Command{
+		Use:   "generate-batch",
+		Short: "Generate many keys at once into a CSV file",
+		Long: "Writes address,publicKey,encryptedPrivateKey rows, the private key as keystore JSON " +
+			"encrypted with the passphrase. With --import the keys are also added to the local " +
+			"keystore as batch_001, batch_002...",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if batchOutput == "" {
+				return fmt.Errorf("no output file specified")
+			}
+			if _, err := os.Stat(batchOutput); err == nil {
+				return fmt.Errorf("%s already exists", batchOutput)
+			}
+			passphrase, err := getPassphraseWithConfirm()
+			if err != nil {
+				return err
+			}
+
+			keys, err := account.GenerateKeys(batchCount, runtime.NumCPU(), passphrase)
+			if err != nil {
+				return err
+			}
+			file, err := os.OpenFile(batchOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			w := csv.NewWriter(file)
+			w.Write([]string{"address", "publicKey", "encryptedPrivateKey"})
+			for _, key := range keys {
+				w.Write([]string{key.Address, key.PublicKey, string(key.EncryptedKey)})
+			}
+			w.Flush()
+			if err = w.Error(); err != nil {
+				return err
+			}
+			fmt.Printf("%d keys written to %s\n", len(keys), batchOutput)
+
+			if batchImport {
+				names, err := account.ImportBatchKeys(keys, "batch", passphrase, runtime.NumCPU())
+				if err != nil {
+					return fmt.Errorf("imported %d of %d keys: %v", len(names), len(keys), err)
+				}
+				fmt.Printf("Imported as %s to %s\n", names[0], names[len(names)-1])
+			}
+			return nil
+		},
+	}
+	cmdGenerateBatch.Flags().IntVar(&batchCount, "count", 1, "number of keys to generate")
+	cmdGenerateBatch.Flags().StringVar(&batchOutput, "output", "", "CSV file to create")
+	cmdGenerateBatch.Flags().BoolVar(&batchImport, "import", false, "also import the keys into the local keystore")
+
 	randomPrivateKey := &cobra.Command{
 		Use:   "random-pk",
 		Short: "export a random private key",
@@ -274,8 +421,69 @@ func keysSub() []*cobra.Command {
 		},
 	}
 
-	return []*cobra.Command{cmdList, cmdLocation, cmdAdd, cmdRemove, cmdMnemonic, cmdRecoverMnemonic, cmdImportKS, cmdImportPK,
-		cmdExportKS, cmdExportPK, randomPrivateKey, addressFromPrivateKey}
+	cmdAlias := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage address aliases usable in place of addresses",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+	cmdAlias.AddCommand(aliasSub()...)
+
+	return []*cobra.Command{cmdList, cmdLocation, cmdAdd, cmdRemove, cmdMnemonic, cmdRecoverMnemonic, cmdImportKS, cmdImportPK, cmdImportHex,
+		cmdExportKS, cmdExportPK, cmdShowMnemonic, cmdGenerateBatch, randomPrivateKey, addressFromPrivateKey, cmdAlias}
+}
+
+func aliasSub() []*cobra.Command {
+	cmdSet := &cobra.Command{
+		Use:   "set <ALIAS> <ADDRESS>",
+		Short: "Define an alias for an address",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := store.SetAlias(args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("Alias %s set to %s\n", args[0], args[1])
+			return nil
+		},
+	}
+
+	cmdList := &cobra.Command{
+		Use:   "list",
+		Short: "List all aliases",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			aliases, err := store.Aliases()
+			if err != nil {
+				return err
+			}
+			names, err := store.AliasNames()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%-24s\t\t%23s\n", "ALIAS", "ADDRESS")
+			for _, name := range names {
+				fmt.Printf("%-48s\t%s\n", name, aliases[name])
+			}
+			return nil
+		},
+	}
+
+	cmdRemove := &cobra.Command{
+		Use:   "remove <ALIAS>",
+		Short: "Remove an alias",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := store.RemoveAlias(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Alias %s removed\n", args[0])
+			return nil
+		},
+	}
+
+	return []*cobra.Command{cmdSet, cmdList, cmdRemove}
 }
 
 func init() {
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/spf13/cobra"
+)
+
+func nodeSub() []*cobra.Command {
+	cmdHealth := &cobra.Command{
+		Use:   "health",
+		Short: "check node head block is in sync with the local clock, fails when behind",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start := time.Now()
+			synced, lag, err := conn.IsFullySynced()
+			if err != nil {
+				return err
+			}
+			rtt := time.Since(start)
+
+			if noPrettyOutput {
+				fmt.Println(synced, lag, rtt)
+			} else {
+				result := make(map[string]interface{})
+				result["node"] = conn.Address
+				result["synced"] = synced
+				result["lagMs"] = lag.Milliseconds()
+				result["rttMs"] = rtt.Milliseconds()
+
+				asJSON, _ := json.Marshal(result)
+				fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			}
+
+			if !synced {
+				return fmt.Errorf("node is %s behind", lag.Round(time.Millisecond))
+			}
+			return nil
+		},
+	}
+
+	cmdPeers := &cobra.Command{
+		Use:   "peers",
+		Short: "list the peers known by the node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			peers, err := conn.ListPeers()
+			if err != nil {
+				return err
+			}
+			if noPrettyOutput {
+				for _, peer := range peers {
+					fmt.Printf("%s:%d\n", peer.Host, peer.Port)
+				}
+				return nil
+			}
+			asJSON, _ := json.Marshal(peers)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	cmdSetDefault := &cobra.Command{
+		Use:   "set-default <HOST:PORT>",
+		Short: "save the node used when --node is not given",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			address := args[0]
+			if !strings.Contains(address, ":") {
+				address = address + ":50051"
+			}
+			config.Node = address
+			if err := SaveConfig(config); err != nil {
+				return err
+			}
+			fmt.Printf("default node set to %s in %s\n", address, DefaultConfigFile)
+			return nil
+		},
+	}
+
+	cmdGetDefault := &cobra.Command{
+		Use:   "get-default",
+		Short: "print the node used when --node is not given",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println(config.Node)
+			return nil
+		},
+	}
+
+	return []*cobra.Command{cmdHealth, cmdPeers, cmdSetDefault, cmdGetDefault}
+}
+
+func init() {
+	cmdNode := &cobra.Command{
+		Use:   "node",
+		Short: "Connected node status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdNode.AddCommand(nodeSub()...)
+	RootCmd.AddCommand(cmdNode)
+}
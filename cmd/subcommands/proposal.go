@@ -118,7 +118,7 @@ func proposalSub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["from"] = signerAddress.String()
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -173,7 +173,7 @@ func proposalSub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["from"] = signerAddress.String()
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -246,7 +246,7 @@ func proposalSub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["from"] = signerAddress.String()
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	c "github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
+	"github.com/fbsobreira/gotron-sdk/pkg/tip712"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
@@ -42,8 +45,10 @@ var (
 	keyStoreDir            string
 	givenFilePath          string
 	timeout                uint32
+	permissionID           int32
 	withTLS                bool
 	apiKey                 string
+	expectChainID          string
 	conn                   *client.GrpcClient
 	// RootCmd is single entry point of the CLI
 	RootCmd = &cobra.Command{
@@ -79,6 +84,14 @@ var (
 				return err
 			}
 
+			if len(expectChainID) > 0 {
+				id, err := parseChainID(expectChainID)
+				if err != nil {
+					return err
+				}
+				conn.SetExpectedChainID(id)
+			}
+
 			if len(signer) > 0 {
 				var err error
 				if signerAddress, err = findAddress(signer); err != nil {
@@ -119,6 +132,8 @@ func init() {
 	RootCmd.PersistentFlags().StringVarP(&node, "node", "n", config.Node, "<host>")
 	RootCmd.PersistentFlags().StringVarP(&apiKey, "apiKey", "k", config.APIKey, "<api-key>")
 	RootCmd.PersistentFlags().BoolVar(&withTLS, "withTLS", config.WithTLS, "<bool>")
+	RootCmd.PersistentFlags().StringVar(&expectChainID, "expect-chain-id", "",
+		"refuse to broadcast unless the node is on this network: mainnet, shasta, nile or a chain ID")
 	RootCmd.PersistentFlags().BoolVar(
 		&noPrettyOutput, "no-pretty", config.NoPretty, "Disable pretty print JSON outputs",
 	)
@@ -128,6 +143,7 @@ func init() {
 
 	RootCmd.PersistentFlags().BoolVarP(&useLedgerWallet, "ledger", "e", config.Ledger, "Use ledger hardware wallet")
 	RootCmd.PersistentFlags().StringVar(&givenFilePath, "file", "", "Path to file for given command when applicable")
+	RootCmd.PersistentFlags().Int32Var(&permissionID, "permission-id", 0, "account permission used to sign (0 owner, 1 witness, 2+ active)")
 
 	// Password
 	RootCmd.PersistentFlags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
@@ -247,15 +263,32 @@ func findAddress(value string) (tronAddress, error) {
 	// Check if input valid one address
 	address := tronAddress{}
 	if err := address.Set(value); err != nil {
-		// Check if input is valid account name
-		if acc, err := store.AddressFromAccountName(value); err == nil {
-			return tronAddress{acc}, nil
+		// Check if input is valid account name or a name known to a resolver
+		if acc, err := store.ResolveAddress(value); err == nil {
+			return tronAddress{acc.String()}, nil
 		}
 		return address, fmt.Errorf("Invalid address/Invalid account name: %s", value)
 	}
 	return address, nil
 }
 
+// parseChainID accepts a network name or a chain ID in decimal or 0x hex
+func parseChainID(value string) (uint32, error) {
+	switch strings.ToLower(value) {
+	case "mainnet":
+		return tip712.MainnetChainID, nil
+	case "shasta":
+		return tip712.ShastaChainID, nil
+	case "nile":
+		return tip712.NileChainID, nil
+	}
+	id, err := strconv.ParseUint(value, 0, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid chain ID: %s", value)
+	}
+	return uint32(id), nil
+}
+
 func opts(ctlr *transaction.Controller) {
 	if dryRun {
 		ctlr.Behavior.DryRun = true
@@ -268,6 +301,24 @@ func opts(ctlr *transaction.Controller) {
 	} else if timeout > 0 {
 		ctlr.Behavior.ConfirmationWaitTime = timeout
 	}
+	if permissionID != 0 {
+		transaction.WithCustomPermission(permissionID)(ctlr)
+	}
+}
+
+// stdin shared by the prompts, a reader per prompt would buffer ahead and
+// swallow the answers of the next ones when input is piped
+var stdin = bufio.NewReader(os.Stdin)
+
+// confirmPrompt asks a yes/no question on the terminal, anything but y/yes is a no
+func confirmPrompt(question string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := stdin.ReadString('\n')
+	if err != nil && len(answer) == 0 {
+		return false, err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
 }
 
 // getPassphrase fetches the correct passphrase depending on if a file is available to
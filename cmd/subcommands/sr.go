@@ -13,9 +13,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// srStatsMaxHistory latest blocks a node returns in a single request
+const srStatsMaxHistory = 99
+
 var (
-	electedOnly bool
-	brokerage   bool
+	electedOnly    bool
+	brokerage      bool
+	srStatsHistory int64
 )
 
 func srSub() []*cobra.Command {
@@ -117,7 +121,7 @@ func srSub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["from"] = signerAddress.String()
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -174,7 +178,7 @@ func srSub() []*cobra.Command {
 
 			result := make(map[string]interface{})
 			result["from"] = signerAddress.String()
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -189,7 +193,71 @@ func srSub() []*cobra.Command {
 		},
 	}
 
-	return []*cobra.Command{cmdList, cmdCreate, cmdUpdateBrokerage}
+	cmdStats := &cobra.Command{
+		Use:     "stats <SR_ADDRESS>",
+		Short:   "show production, votes and brokerage of a witness",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: validateAddress,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if srStatsHistory < 0 || srStatsHistory > srStatsMaxHistory {
+				return fmt.Errorf("history must be between 0 and %d", srStatsMaxHistory)
+			}
+			stats, err := conn.GetWitnessStats(addr.String())
+			if err != nil {
+				return err
+			}
+
+			produced := make([]map[string]interface{}, 0)
+			if srStatsHistory > 0 {
+				blocks, err := conn.GetBlockByLatestNum(srStatsHistory)
+				if err != nil {
+					return err
+				}
+				for _, block := range blocks.GetBlock() {
+					header := block.GetBlockHeader().GetRawData()
+					if address.Address(header.GetWitnessAddress()).String() != stats.Address {
+						continue
+					}
+					produced = append(produced, map[string]interface{}{
+						"number":    header.GetNumber(),
+						"timestamp": header.GetTimestamp(),
+						"txCount":   len(block.GetTransactions()),
+					})
+				}
+			}
+
+			if noPrettyOutput {
+				fmt.Println(stats, produced)
+				return nil
+			}
+
+			prod := float64(0)
+			if stats.TotalProduced+stats.TotalMissed > 0 {
+				prod = (float64(stats.TotalProduced) / float64(stats.TotalProduced+stats.TotalMissed)) * 100
+			}
+			result := map[string]interface{}{
+				"address":        stats.Address,
+				"url":            stats.URL,
+				"elected":        stats.IsJobs,
+				"votes":          stats.VoteCount,
+				"blocksProduced": stats.TotalProduced,
+				"blocksMissed":   stats.TotalMissed,
+				"productivity":   prod,
+				"lastBlock":      stats.LastBlockNum,
+				"brokerage":      stats.Brokerage,
+			}
+			if srStatsHistory > 0 {
+				result["recentBlocks"] = produced
+			}
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdStats.Flags().Int64Var(&srStatsHistory, "history", 0,
+		"check the latest N blocks and list those produced by the witness")
+
+	return []*cobra.Command{cmdList, cmdCreate, cmdUpdateBrokerage, cmdStats}
 }
 
 func init() {
@@ -56,22 +56,19 @@ func trc10Sub() []*cobra.Command {
 					return err
 				}
 			} else {
-				// use as float
-				ratio, err := strconv.ParseFloat(args[5], 32)
+				// decimal ratio, as TRX per token with up to 6 decimals
+				ratio, err := common.ParseAmountInt64(args[5], 6)
 				if err != nil {
-					return err
+					return fmt.Errorf("invalid ratio: %v", err)
 				}
-				// round up to 6 decimals
-				p := math.Pow10(6)
-				ratio = float64(int(ratio*p)) / p
-				for float64(int64(ratio)) != ratio && tokenNum <= int64(math.Pow10(6)) {
-					ratio *= 10
-					tokenNum *= 10
+				trxNum, tokenNum = ratio, 1000000
+				for trxNum%10 == 0 && tokenNum > 1 {
+					trxNum /= 10
+					tokenNum /= 10
 				}
-				if tokenNum > int64(math.Pow10(6)) {
+				if trxNum <= 0 || trxNum > math.MaxInt32 {
 					return fmt.Errorf("invalid ratio")
 				}
-				trxNum = int64(ratio)
 			}
 
 			frozenSupply := make(map[string]string)
@@ -84,22 +81,18 @@ func trc10Sub() []*cobra.Command {
 					return fmt.Errorf("frozen supply date colision %s:%s -> %s", frozenSupplyKeyValue[0], frozenSupply[frozenSupplyKeyValue[0]], value)
 				}
 				// update frozen supply with decimals
-				fSupply := ""
-				if s, err := strconv.ParseFloat(frozenSupplyKeyValue[1], 64); err == nil {
-					s *= math.Pow10(int(issueDecimals))
-					fSupply = strconv.FormatInt(int64(s), 10)
-				} else {
+				fSupply, err := common.ParseAmountInt64(frozenSupplyKeyValue[1], int(issueDecimals))
+				if err != nil {
 					return fmt.Errorf("invalid frozen supply: %s", value)
 				}
-				frozenSupply[frozenSupplyKeyValue[0]] = fSupply
+				frozenSupply[frozenSupplyKeyValue[0]] = strconv.FormatInt(fSupply, 10)
 			}
 
-			totalSupply, err := strconv.ParseInt(args[4], 10, 64)
+			// total supply with decimals
+			totalSupply, err := common.ParseAmountInt64(args[4], int(issueDecimals))
 			if err != nil {
 				return err
 			}
-			// update total supply with decimals
-			totalSupply = int64(float64(totalSupply) * math.Pow10(int(issueDecimals)))
 			tx, err := conn.AssetIssue(signerAddress.String(),
 				args[0], // Name
 				args[1], // Description
@@ -140,7 +133,7 @@ func trc10Sub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -169,12 +162,6 @@ func trc10Sub() []*cobra.Command {
 			if signerAddress.String() == "" {
 				return fmt.Errorf("no signer specified")
 			}
-			// get amount
-			value, err := strconv.ParseFloat(args[1], 64)
-			if err != nil {
-				return err
-			}
-
 			// Get asset information
 			// check if possible id
 			tokenID := ""
@@ -203,8 +190,11 @@ func trc10Sub() []*cobra.Command {
 				}
 			}
 
-			value = value * math.Pow10(int(tokenDecimals))
-			tx, err := conn.TransferAsset(signerAddress.String(), addr.String(), tokenID, int64(value))
+			valueInt, err := common.ParseAmountInt64(args[1], int(tokenDecimals))
+			if err != nil {
+				return err
+			}
+			tx, err := conn.TransferAsset(signerAddress.String(), addr.String(), tokenID, valueInt)
 			if err != nil {
 				return err
 			}
@@ -230,7 +220,7 @@ func trc10Sub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -253,8 +243,8 @@ func trc10Sub() []*cobra.Command {
 			if signerAddress.String() == "" {
 				return fmt.Errorf("no signer specified")
 			}
-			// get amount
-			value, err := strconv.ParseFloat(args[1], 64)
+			// participate amount is TRX value
+			valueInt, err := common.ParseAmountInt64(args[1], common.AmountDecimalPoint)
 			if err != nil {
 				return err
 			}
@@ -293,8 +283,6 @@ func trc10Sub() []*cobra.Command {
 				}
 			}
 
-			// participate amount is TRX value
-			valueInt := int64(value * math.Pow10(6))
 			tx, err := conn.ParticipateAssetIssue(signerAddress.String(), issuerAddress, tokenID, valueInt)
 			if err != nil {
 				return err
@@ -321,7 +309,7 @@ func trc10Sub() []*cobra.Command {
 			}
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["receipt"] = map[string]interface{}{
@@ -329,7 +317,7 @@ func trc10Sub() []*cobra.Command {
 				"netFee":      ctrlr.Receipt.Receipt.NetFee,
 				"netUsage":    ctrlr.Receipt.Receipt.NetUsage,
 				"price":       price,
-				"cost":        value,
+				"cost":        args[1],
 				"tokenAmount": float64(valueInt) * price,
 			}
 
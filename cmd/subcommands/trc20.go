@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/big"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/common/decimals"
@@ -14,6 +20,19 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	trc20Split            int
+	trc20AddressesFile    string
+	trc20BatchConcurrency int
+	trc20Deadline         time.Duration
+)
+
+// maxTxExpiration longest expiration the nodes accept for a transaction
+const maxTxExpiration = 24 * time.Hour
+
+// splitConfirmThreshold splits above this many transfers ask for confirmation
+const splitConfirmThreshold = 5
+
 func trc20Sub() []*cobra.Command {
 	cmdSend := &cobra.Command{
 		Use:     "send <ADDRESS_TO> <AMOUNT> <CONTRACT_ADDRESS> ",
@@ -24,12 +43,6 @@ func trc20Sub() []*cobra.Command {
 			if signerAddress.String() == "" {
 				return fmt.Errorf("no signer specified")
 			}
-			// get amount
-			value, ok := decimals.FromString(args[1])
-			if !ok {
-				return fmt.Errorf("cannot parse value %s", args[1])
-			}
-
 			// get contract address
 			contract, err := findAddress(args[2])
 			if err != nil {
@@ -41,7 +54,13 @@ func trc20Sub() []*cobra.Command {
 				tokenDecimals = big.NewInt(0)
 			}
 
-			amount, _ := decimals.ApplyDecimals(value, tokenDecimals.Int64())
+			amount, err := common.ParseAmount(args[1], int(tokenDecimals.Int64()))
+			if err != nil {
+				return err
+			}
+			if trc20Split > 1 {
+				return trc20SplitSend(contract.String(), amount, trc20Split)
+			}
 			tx, err := conn.TRC20Send(signerAddress.String(), addr.String(), contract.String(), amount, feeLimit)
 			if err != nil {
 				return err
@@ -70,7 +89,7 @@ func trc20Sub() []*cobra.Command {
 			addrResult := address.Address(ctrlr.Receipt.ContractAddress).String()
 
 			result := make(map[string]interface{})
-			result["txID"] = common.BytesToHexString(tx.GetTxid())
+			result["txID"], _ = ctrlr.TransactionHash()
 			result["blockNumber"] = ctrlr.Receipt.BlockNumber
 			result["message"] = string(ctrlr.Result.Message)
 			result["contractAddress"] = addrResult
@@ -92,6 +111,8 @@ func trc20Sub() []*cobra.Command {
 		},
 	}
 
+	cmdSend.Flags().IntVar(&trc20Split, "split", 1, "send the amount as N equal transfers, remainder goes in the first one")
+
 	cmdBalance := &cobra.Command{
 		Use:     "balance <ADDRESS_TO> <CONTRACT_ADDRESS> ",
 		Short:   "get TRC20 balance from contract",
@@ -137,7 +158,319 @@ func trc20Sub() []*cobra.Command {
 		},
 	}
 
-	return []*cobra.Command{cmdSend, cmdBalance}
+	cmdApproveMax := &cobra.Command{
+		Use:   "approve-max <CONTRACT_ADDRESS> <SPENDER>",
+		Short: "approve the spender to transfer any amount of the signer tokens",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return trc20SetApproval(args[0], args[1], client.TRC20MaxAllowance())
+		},
+	}
+
+	cmdRevokeApproval := &cobra.Command{
+		Use:   "revoke-approval <CONTRACT_ADDRESS> <SPENDER>",
+		Short: "set the spender allowance over the signer tokens to zero",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return trc20SetApproval(args[0], args[1], big.NewInt(0))
+		},
+	}
+
+	cmdBatchBalance := &cobra.Command{
+		Use:   "batch-balance <CONTRACT_ADDRESS>",
+		Short: "print as CSV the TRC20 balance of every address in --addresses",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contract, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			if trc20AddressesFile == "" {
+				return fmt.Errorf("--addresses is required")
+			}
+			content, err := ioutil.ReadFile(trc20AddressesFile)
+			if err != nil {
+				return err
+			}
+			addresses := make([]string, 0)
+			for _, line := range strings.Split(string(content), "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					addresses = append(addresses, line)
+				}
+			}
+
+			tokenDecimals, err := conn.TRC20GetDecimals(contract.String())
+			if err != nil {
+				tokenDecimals = big.NewInt(0)
+			}
+			results, err := conn.TRC20BatchBalances(addresses, contract.String(), trc20BatchConcurrency)
+			if err != nil {
+				return err
+			}
+
+			w := csv.NewWriter(os.Stdout)
+			w.Write([]string{"address", "balance", "formatted_balance"})
+			failed := 0
+			for _, result := range results {
+				if result.Err != nil {
+					failed++
+					fmt.Fprintf(os.Stderr, "%s: %v\n", result.Address, result.Err)
+					continue
+				}
+				formatted := decimals.RemoveDecimals(result.Balance, tokenDecimals.Int64())
+				w.Write([]string{result.Address, result.Balance.String(), formatted.String()})
+			}
+			w.Flush()
+			if err = w.Error(); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d balance(s) failed", failed, len(results))
+			}
+			return nil
+		},
+	}
+	cmdBatchBalance.Flags().StringVar(&trc20AddressesFile, "addresses", "", "file with one address per line")
+	cmdBatchBalance.Flags().IntVar(&trc20BatchConcurrency, "concurrency", 20, "balance queries in flight")
+
+	cmdTransferAndCall := &cobra.Command{
+		Use:   "transfer-and-call <CONTRACT_ADDRESS> <DATA_HEX> <ADDRESS_TO> <AMOUNT>",
+		Short: "send TRC20 tokens to a contract calling transferAndCall(address,uint256,bytes)",
+		Long: `Send TRC20 tokens to a contract, e.g. a DEX router, which the token then notifies
+with DATA_HEX in the same transaction. The recipient must be a contract.
+
+DATA_HEX is passed as is, router parameters such as the minimum output must be
+encoded in it. --deadline sets the transaction expiration, it is not broadcast
+after that.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			if trc20Deadline < 0 || trc20Deadline > maxTxExpiration {
+				return fmt.Errorf("invalid deadline: %s, max is %s", trc20Deadline, maxTxExpiration)
+			}
+			contract, err := findAddress(args[0])
+			if err != nil {
+				return err
+			}
+			data, err := common.FromHex(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid data: %v", err)
+			}
+			to, err := findAddress(args[2])
+			if err != nil {
+				return err
+			}
+			tokenDecimals, err := conn.TRC20GetDecimals(contract.String())
+			if err != nil {
+				return fmt.Errorf("get decimals of %s: %v", contract.String(), err)
+			}
+			amount, err := common.ParseAmount(args[3], int(tokenDecimals.Int64()))
+			if err != nil {
+				return err
+			}
+
+			tx, err := conn.TRC20TransferAndCall(signerAddress.String(), to.String(), contract.String(), amount, data, feeLimit)
+			if err != nil {
+				return err
+			}
+			if trc20Deadline > 0 {
+				tx.Transaction.RawData.Expiration = time.Now().Add(trc20Deadline).UnixMilli()
+			}
+
+			var ctrlr *transaction.Controller
+			if useLedgerWallet {
+				account := keystore.Account{Address: signerAddress.GetAddress()}
+				ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+				if err != nil {
+					return err
+				}
+				ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+			}
+			if err = ctrlr.ExecuteTransaction(); err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(tx)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["txID"], _ = ctrlr.TransactionHash()
+			result["blockNumber"] = ctrlr.Receipt.BlockNumber
+			result["contract"] = contract.String()
+			result["to"] = to.String()
+			result["amount"] = amount.String()
+			result["expiration"] = time.UnixMilli(tx.Transaction.RawData.Expiration).Format(time.RFC3339)
+			result["success"] = ctrlr.GetResultError() == nil
+			result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+			result["receipt"] = map[string]interface{}{
+				"fee":              ctrlr.Receipt.Fee,
+				"energyFee":        ctrlr.Receipt.Receipt.EnergyFee,
+				"energyUsageTotal": ctrlr.Receipt.Receipt.EnergyUsageTotal,
+				"netFee":           ctrlr.Receipt.Receipt.NetFee,
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdTransferAndCall.Flags().Int64Var(&feeLimit, "feeLimit", 100000000, "fee limit in SUN, router calls spend more energy than a transfer")
+	cmdTransferAndCall.Flags().DurationVar(&trc20Deadline, "deadline", 0, "expire the transaction after this long, e.g. 5m [optional]")
+
+	return []*cobra.Command{cmdSend, cmdBalance, cmdApproveMax, cmdRevokeApproval, cmdBatchBalance, cmdTransferAndCall}
+}
+
+// trc20SetApproval sends approve(spender, amount) from the signer, printing
+// the allowance before and after
+func trc20SetApproval(contractArg, spenderArg string, amount *big.Int) error {
+	if signerAddress.String() == "" {
+		return fmt.Errorf("no signer specified")
+	}
+	contract, err := findAddress(contractArg)
+	if err != nil {
+		return err
+	}
+	spender, err := findAddress(spenderArg)
+	if err != nil {
+		return err
+	}
+
+	before, err := conn.TRC20Allowance(signerAddress.String(), spender.String(), contract.String())
+	if err != nil {
+		return err
+	}
+	fmt.Println("allowance before:", before.String())
+
+	tx, err := conn.TRC20Approve(signerAddress.String(), spender.String(), contract.String(), amount, feeLimit)
+	if err != nil {
+		return err
+	}
+
+	var ctrlr *transaction.Controller
+	if useLedgerWallet {
+		account := keystore.Account{Address: signerAddress.GetAddress()}
+		ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+	} else {
+		ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+		if err != nil {
+			return err
+		}
+		ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+	}
+	if err = ctrlr.ExecuteTransaction(); err != nil {
+		return err
+	}
+
+	// before the receipt the node still reports the old value, so the new
+	// one is only read once confirmed, not on dry runs or with --no-wait
+	var after *big.Int
+	if ctrlr.Receipt.GetBlockNumber() > 0 {
+		if after, err = conn.TRC20Allowance(signerAddress.String(), spender.String(), contract.String()); err != nil {
+			return err
+		}
+		fmt.Println("allowance after:", after.String())
+	} else {
+		fmt.Println("allowance after: pending confirmation")
+	}
+
+	if noPrettyOutput {
+		fmt.Println(tx)
+		return nil
+	}
+
+	result := make(map[string]interface{})
+	result["txID"], _ = ctrlr.TransactionHash()
+	result["blockNumber"] = ctrlr.Receipt.BlockNumber
+	result["contract"] = contract.String()
+	result["spender"] = spender.String()
+	result["allowanceBefore"] = before.String()
+	if after != nil {
+		result["allowanceAfter"] = after.String()
+	}
+	result["success"] = ctrlr.GetResultError() == nil
+	result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+
+	asJSON, _ := json.Marshal(result)
+	fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+	return nil
+}
+
+// trc20SplitSend sends amount to addr in parts transactions, one after the other
+func trc20SplitSend(contract string, amount *big.Int, parts int) error {
+	if big.NewInt(int64(parts)).Cmp(amount) > 0 {
+		return fmt.Errorf("amount %s can not be split in %d transfers", amount.String(), parts)
+	}
+	if parts > splitConfirmThreshold {
+		ok, err := confirmPrompt(fmt.Sprintf("Send %d separate transfers to %s?", parts, addr.String()))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("aborted")
+		}
+	}
+
+	chunk, remainder := new(big.Int).DivMod(amount, big.NewInt(int64(parts)), new(big.Int))
+
+	// sign with passphrase as every transfer is signed by the same account
+	var (
+		ks   *keystore.KeyStore
+		acct *keystore.Account
+		err  error
+	)
+	if useLedgerWallet {
+		acct = &keystore.Account{Address: signerAddress.GetAddress()}
+	} else {
+		ks, acct, err = store.UnlockedKeystore(signerAddress.String(), passphrase)
+		if err != nil {
+			return err
+		}
+	}
+	splitOpts := []func(*transaction.Controller){opts}
+	if !useLedgerWallet {
+		splitOpts = append(splitOpts, transaction.WithPassphrase(passphrase))
+	}
+
+	txIDs := make([]string, 0, parts)
+	for i := 0; i < parts; i++ {
+		value := new(big.Int).Set(chunk)
+		if i == 0 {
+			value.Add(value, remainder)
+		}
+		tx, err := conn.TRC20Send(signerAddress.String(), addr.String(), contract, value, feeLimit)
+		if err != nil {
+			return fmt.Errorf("transfer %d/%d: %v", i+1, parts, err)
+		}
+		ctrlr := transaction.NewController(conn, ks, acct, tx.Transaction, splitOpts...)
+		if err = ctrlr.ExecuteTransaction(); err != nil {
+			return fmt.Errorf("transfer %d/%d: %v", i+1, parts, err)
+		}
+		if err = ctrlr.GetResultError(); err != nil {
+			return fmt.Errorf("transfer %d/%d: %v", i+1, parts, err)
+		}
+		txID, _ := ctrlr.TransactionHash()
+		txIDs = append(txIDs, txID)
+		fmt.Printf("transfer %d/%d: %s sent, txID %s\n", i+1, parts, value.String(), txID)
+	}
+
+	if noPrettyOutput {
+		return nil
+	}
+	result := make(map[string]interface{})
+	result["to"] = addr.String()
+	result["contract"] = contract
+	result["amount"] = amount.String()
+	result["txIDs"] = txIDs
+
+	asJSON, _ := json.Marshal(result)
+	fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+	return nil
 }
 
 func init() {
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+func txSub() []*cobra.Command {
+	cmdDecode := &cobra.Command{
+		Use:   "decode <TX_HEX>",
+		Short: "decode a serialized transaction without contacting a node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tx, err := decodeTransactionHex(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Print(common.FormatTransaction(tx))
+			return nil
+		},
+	}
+
+	cmdDecodeEvents := &cobra.Command{
+		Use:   "decode-events <TX_ID>",
+		Short: "decode the event logs of a transaction",
+		Long:  "Without --abi each log is decoded with the ABI of the contract that emitted it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var fileABI *core.SmartContract_ABI
+			if abiFile != "" {
+				var err error
+				if fileABI, err = loadABIFile(abiFile); err != nil {
+					return err
+				}
+			}
+			info, err := conn.GetTransactionInfoByID(args[0])
+			if err != nil {
+				return err
+			}
+
+			contractABIs := make(map[string]*core.SmartContract_ABI)
+			events := make([]map[string]interface{}, 0, len(info.GetLog()))
+			for i, log := range info.GetLog() {
+				emitter := common.EncodeCheck(append([]byte{0x41}, log.GetAddress()...))
+				ABI := fileABI
+				if ABI == nil {
+					if _, ok := contractABIs[emitter]; !ok {
+						// contracts without ABI keep their logs raw
+						contractABIs[emitter], _ = conn.GetContractABI(emitter)
+					}
+					ABI = contractABIs[emitter]
+				}
+
+				event := map[string]interface{}{
+					"logIndex": i,
+					"contract": emitter,
+				}
+				decoded, err := abi.DecodeEvent(ABI, log)
+				switch {
+				case err == nil:
+					event["event"] = decoded.Signature
+					event["params"] = decoded.Params
+				case err == abi.ErrEventNotFound:
+					// anonymous events have no signature topic to match
+					event["topics"] = common.ToHexArray(log.GetTopics())
+					event["data"] = common.BytesToHexString(log.GetData())
+				default:
+					return fmt.Errorf("log %d: %v", i, err)
+				}
+				events = append(events, event)
+			}
+
+			asJSON, _ := json.Marshal(events)
+			if noPrettyOutput {
+				fmt.Println(string(asJSON))
+				return nil
+			}
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdDecodeEvents.Flags().StringVar(&abiFile, "abi", "", "abi file used to decode events")
+
+	cmdMemo := &cobra.Command{
+		Use:   "memo <TX_ID>",
+		Short: "print the memo of a transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			memo, hasMemo, err := conn.GetMemoByTransactionId(args[0])
+			if err != nil {
+				return err
+			}
+			if noPrettyOutput {
+				fmt.Println(memo)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["txID"] = args[0]
+			result["hasMemo"] = hasMemo
+			result["memo"] = memo
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+
+	return []*cobra.Command{cmdDecode, cmdDecodeEvents, cmdMemo}
+}
+
+// decodeTransactionHex parses a protobuf encoded core.Transaction
+func decodeTransactionHex(txHex string) (*core.Transaction, error) {
+	txBytes, err := common.FromHex(strings.TrimSpace(txHex))
+	if err != nil {
+		return nil, err
+	}
+	tx := &core.Transaction{}
+	if err = proto.Unmarshal(txBytes, tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %v", err)
+	}
+	return tx, nil
+}
+
+func init() {
+	cmdTx := &cobra.Command{
+		Use:   "tx",
+		Short: "Transaction inspection tools",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdTx.AddCommand(txSub()...)
+	RootCmd.AddCommand(cmdTx)
+}
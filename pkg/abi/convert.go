@@ -0,0 +1,84 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// jsonEntry standard Solidity JSON ABI entry
+type jsonEntry struct {
+	Type            string      `json:"type"`
+	Name            string      `json:"name,omitempty"`
+	Inputs          []jsonParam `json:"inputs"`
+	Outputs         []jsonParam `json:"outputs,omitempty"`
+	StateMutability string      `json:"stateMutability,omitempty"`
+	Anonymous       bool        `json:"anonymous,omitempty"`
+	Constant        bool        `json:"constant,omitempty"`
+	Payable         bool        `json:"payable,omitempty"`
+}
+
+type jsonParam struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+var entryTypes = map[core.SmartContract_ABI_Entry_EntryType]string{
+	core.SmartContract_ABI_Entry_Constructor: "constructor",
+	core.SmartContract_ABI_Entry_Function:    "function",
+	core.SmartContract_ABI_Entry_Event:       "event",
+	core.SmartContract_ABI_Entry_Fallback:    "fallback",
+	core.SmartContract_ABI_Entry_Receive:     "receive",
+	core.SmartContract_ABI_Entry_Error:       "error",
+}
+
+var stateMutabilities = map[core.SmartContract_ABI_Entry_StateMutabilityType]string{
+	core.SmartContract_ABI_Entry_Pure:       "pure",
+	core.SmartContract_ABI_Entry_View:       "view",
+	core.SmartContract_ABI_Entry_Nonpayable: "nonpayable",
+	core.SmartContract_ABI_Entry_Payable:    "payable",
+}
+
+// FromProto converts the ABI stored on chain, as returned by GetContract, into
+// standard Solidity JSON ABI readable by go-ethereum abi.JSON and other tools.
+// The on-chain format has no tuple components, tuples can not be described.
+func FromProto(ABI *core.SmartContract_ABI) ([]byte, error) {
+	entries := make([]jsonEntry, 0, len(ABI.GetEntrys()))
+	for _, e := range ABI.GetEntrys() {
+		entryType, ok := entryTypes[e.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown ABI entry type for %s: %s", e.Name, e.Type.String())
+		}
+		entry := jsonEntry{
+			Type:            entryType,
+			Name:            e.Name,
+			Inputs:          fromProtoParams(e.Inputs),
+			Outputs:         fromProtoParams(e.Outputs),
+			StateMutability: stateMutabilities[e.StateMutability],
+			Anonymous:       e.Anonymous,
+			Constant:        e.Constant,
+			Payable:         e.Payable,
+		}
+		if e.Type == core.SmartContract_ABI_Entry_Event || e.Type == core.SmartContract_ABI_Entry_Error {
+			entry.StateMutability = ""
+		}
+		entries = append(entries, entry)
+	}
+	return json.Marshal(entries)
+}
+
+func fromProtoParams(params []*core.SmartContract_ABI_Entry_Param) []jsonParam {
+	list := make([]jsonParam, len(params))
+	for i, p := range params {
+		list[i] = jsonParam{Name: p.Name, Type: p.Type, Indexed: p.Indexed}
+	}
+	return list
+}
+
+// ToProto converts standard Solidity JSON ABI into the on-chain format
+func ToProto(jsonABI []byte) (*core.SmartContract_ABI, error) {
+	return contract.JSONtoABI(string(jsonABI))
+}
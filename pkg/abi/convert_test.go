@@ -0,0 +1,55 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	onChain := &core.SmartContract_ABI{
+		Entrys: []*core.SmartContract_ABI_Entry{
+			{
+				Type:            core.SmartContract_ABI_Entry_Function,
+				Name:            "transfer",
+				Inputs:          []*core.SmartContract_ABI_Entry_Param{{Name: "to", Type: "address"}, {Name: "value", Type: "uint256"}},
+				Outputs:         []*core.SmartContract_ABI_Entry_Param{{Type: "bool"}},
+				StateMutability: core.SmartContract_ABI_Entry_Nonpayable,
+			},
+			{
+				Type:            core.SmartContract_ABI_Entry_Function,
+				Name:            "balanceOf",
+				Constant:        true,
+				Inputs:          []*core.SmartContract_ABI_Entry_Param{{Name: "who", Type: "address"}},
+				Outputs:         []*core.SmartContract_ABI_Entry_Param{{Type: "uint256"}},
+				StateMutability: core.SmartContract_ABI_Entry_View,
+			},
+			{
+				Type: core.SmartContract_ABI_Entry_Event,
+				Name: "Transfer",
+				Inputs: []*core.SmartContract_ABI_Entry_Param{
+					{Name: "from", Type: "address", Indexed: true},
+					{Name: "to", Type: "address", Indexed: true},
+					{Name: "value", Type: "uint256"},
+				},
+			},
+			{
+				Type:            core.SmartContract_ABI_Entry_Receive,
+				Payable:         true,
+				StateMutability: core.SmartContract_ABI_Entry_Payable,
+			},
+		},
+	}
+
+	jsonABI, err := FromProto(onChain)
+	require.Nil(t, err)
+	assert.Contains(t, string(jsonABI),
+		`{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},`)
+
+	back, err := ToProto(jsonABI)
+	require.Nil(t, err)
+	assert.True(t, proto.Equal(onChain, back), "got %v", back)
+}
@@ -0,0 +1,256 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	// ErrEventNotFound is returned when no ABI event matches the log topic
+	ErrEventNotFound = fmt.Errorf("event not found in ABI")
+	// ErrMethodNotFound is returned when no ABI function matches the call selector
+	ErrMethodNotFound = fmt.Errorf("method not found in ABI")
+)
+
+// DecodedParam named value decoded from contract data, addresses are
+// base58 strings, integers decimal strings and bytes hex strings
+type DecodedParam struct {
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Indexed bool        `json:"indexed,omitempty"`
+	Value   interface{} `json:"value"`
+}
+
+// DecodedEvent contract log decoded with its ABI event, LogIndex is the
+// position of the log in TransactionInfo.Log and is only set by DecodeEvents
+type DecodedEvent struct {
+	Name      string         `json:"name"`
+	Signature string         `json:"signature"`
+	LogIndex  int            `json:"logIndex"`
+	Params    []DecodedParam `json:"params"`
+}
+
+// EntrySignature returns the canonical signature of an ABI entry, e.g. Transfer(address,address,uint256)
+func EntrySignature(entry *core.SmartContract_ABI_Entry) string {
+	types := make([]string, len(entry.Inputs))
+	for i, input := range entry.Inputs {
+		types[i] = input.Type
+	}
+	return entry.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// DecodeEvent decodes a transaction log with the matching ABI event
+func DecodeEvent(ABI *core.SmartContract_ABI, log *core.TransactionInfo_Log) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrEventNotFound
+	}
+	for _, entry := range ABI.GetEntrys() {
+		if entry.Type != core.SmartContract_ABI_Entry_Event || entry.Anonymous {
+			continue
+		}
+		signature := EntrySignature(entry)
+		hasher := sha3.NewLegacyKeccak256()
+		hasher.Write([]byte(signature))
+		if !bytes.Equal(hasher.Sum(nil), log.Topics[0]) {
+			continue
+		}
+
+		event := &DecodedEvent{
+			Name:      entry.Name,
+			Signature: signature,
+			Params:    make([]DecodedParam, len(entry.Inputs)),
+		}
+		nonIndexed := eABI.Arguments{}
+		nonIndexedPos := make([]int, 0)
+		topic := 1
+		for i, input := range entry.Inputs {
+			ty, err := eABI.NewType(input.Type, "", nil)
+			if err != nil {
+				return nil, fmt.Errorf("invalid param %s: %+v", input.Type, err)
+			}
+			event.Params[i] = DecodedParam{Name: input.Name, Type: input.Type, Indexed: input.Indexed}
+			if !input.Indexed {
+				nonIndexed = append(nonIndexed, eABI.Argument{Name: input.Name, Type: ty})
+				nonIndexedPos = append(nonIndexedPos, i)
+				continue
+			}
+			if topic >= len(log.Topics) {
+				return nil, fmt.Errorf("missing topic for %s", input.Name)
+			}
+			switch ty.T {
+			case eABI.StringTy, eABI.BytesTy, eABI.SliceTy, eABI.ArrayTy, eABI.TupleTy:
+				// dynamic indexed values are only available as their hash
+				event.Params[i].Value = toHex(log.Topics[topic])
+			default:
+				values, err := eABI.Arguments{{Type: ty}}.Unpack(log.Topics[topic])
+				if err != nil {
+					return nil, fmt.Errorf("decode %s: %v", input.Name, err)
+				}
+				event.Params[i].Value = formatValue(values[0])
+			}
+			topic++
+		}
+		if len(nonIndexed) > 0 {
+			values, err := nonIndexed.Unpack(log.Data)
+			if err != nil {
+				return nil, fmt.Errorf("decode %s data: %v", entry.Name, err)
+			}
+			for j, v := range values {
+				event.Params[nonIndexedPos[j]].Value = formatValue(v)
+			}
+		}
+		return event, nil
+	}
+	return nil, ErrEventNotFound
+}
+
+// DecodeEvents decodes the logs of a transaction in emission order. The node
+// stores logs in the order the VM emits them, internal calls included, so
+// events are returned in that order with LogIndex set to the log position.
+// Logs without a matching ABI event (e.g. emitted by other contracts) are
+// skipped but keep their index, gaps in LogIndex show where they were.
+func DecodeEvents(ABI *core.SmartContract_ABI, info *core.TransactionInfo) ([]*DecodedEvent, error) {
+	events := make([]*DecodedEvent, 0, len(info.GetLog()))
+	for i, log := range info.GetLog() {
+		event, err := DecodeEvent(ABI, log)
+		if err == ErrEventNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("log %d: %v", i, err)
+		}
+		event.LogIndex = i
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// DecodeInput decodes contract call data into the ABI method name and its parameters
+func DecodeInput(ABI *core.SmartContract_ABI, data []byte) (string, []DecodedParam, error) {
+	if len(data) < 4 {
+		return "", nil, fmt.Errorf("invalid call data length: %d", len(data))
+	}
+	for _, entry := range ABI.GetEntrys() {
+		if entry.Type != core.SmartContract_ABI_Entry_Function {
+			continue
+		}
+		signature := EntrySignature(entry)
+		if !bytes.Equal(Signature(signature), data[:4]) {
+			continue
+		}
+
+		arguments := eABI.Arguments{}
+		params := make([]DecodedParam, len(entry.Inputs))
+		for i, input := range entry.Inputs {
+			ty, err := eABI.NewType(input.Type, "", nil)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid param %s: %+v", input.Type, err)
+			}
+			arguments = append(arguments, eABI.Argument{Name: input.Name, Type: ty})
+			params[i] = DecodedParam{Name: input.Name, Type: input.Type}
+		}
+		values, err := arguments.Unpack(data[4:])
+		if err != nil {
+			return "", nil, fmt.Errorf("decode %s: %v", signature, err)
+		}
+		for i, v := range values {
+			params[i].Value = formatValue(v)
+		}
+		return signature, params, nil
+	}
+	return "", nil, ErrMethodNotFound
+}
+
+// DecodeOutput decodes the data returned by method, given by name or by its
+// signature to pick an overload, e.g. swap(uint256,address)
+func DecodeOutput(ABI *core.SmartContract_ABI, method string, data []byte) ([]DecodedParam, error) {
+	for _, entry := range ABI.GetEntrys() {
+		if entry.Type != core.SmartContract_ABI_Entry_Function {
+			continue
+		}
+		if entry.Name != method && EntrySignature(entry) != method {
+			continue
+		}
+
+		arguments := eABI.Arguments{}
+		params := make([]DecodedParam, len(entry.Outputs))
+		for i, output := range entry.Outputs {
+			ty, err := eABI.NewType(output.Type, "", nil)
+			if err != nil {
+				return nil, fmt.Errorf("invalid param %s: %+v", output.Type, err)
+			}
+			arguments = append(arguments, eABI.Argument{Name: output.Name, Type: ty})
+			params[i] = DecodedParam{Name: output.Name, Type: output.Type}
+		}
+		if len(arguments) == 0 {
+			return params, nil
+		}
+		values, err := arguments.Unpack(data)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s output: %v", EntrySignature(entry), err)
+		}
+		for i, v := range values {
+			params[i].Value = formatValue(v)
+		}
+		return params, nil
+	}
+	return nil, ErrMethodNotFound
+}
+
+// DecodeContractResult decodes the value method returned in a confirmed
+// transaction, the contractResult of its TransactionInfo. Failed calls hold
+// the revert data instead and return an error with the node message.
+func DecodeContractResult(ABI *core.SmartContract_ABI, method string, info *core.TransactionInfo) ([]DecodedParam, error) {
+	if info.GetResult() == core.TransactionInfo_FAILED {
+		return nil, fmt.Errorf("call failed: %s", info.GetResMessage())
+	}
+	var data []byte
+	if results := info.GetContractResult(); len(results) > 0 {
+		data = results[0]
+	}
+	return DecodeOutput(ABI, method, data)
+}
+
+func toHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// formatValue converts decoded ABI values into printable ones
+func formatValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case eCommon.Address:
+		return address.Address(append([]byte{address.TronBytePrefix}, value.Bytes()...)).String()
+	case *big.Int:
+		return value.String()
+	case []byte:
+		return toHex(value)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return toHex(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			list[i] = formatValue(rv.Index(i).Interface())
+		}
+		return list
+	}
+	return v
+}
@@ -0,0 +1,143 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var transferABI = &core.SmartContract_ABI{
+	Entrys: []*core.SmartContract_ABI_Entry{{
+		Type: core.SmartContract_ABI_Entry_Event,
+		Name: "Transfer",
+		Inputs: []*core.SmartContract_ABI_Entry_Param{
+			{Name: "from", Type: "address", Indexed: true},
+			{Name: "to", Type: "address", Indexed: true},
+			{Name: "value", Type: "uint256"},
+		},
+	}},
+}
+
+func TestDecodeEvent(t *testing.T) {
+	from, err := address.Base58ToAddress("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	require.Nil(t, err)
+	to, err := address.Base58ToAddress("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	require.Nil(t, err)
+
+	topic0, _ := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	log := &core.TransactionInfo_Log{
+		Topics: [][]byte{
+			topic0,
+			common.LeftPadBytes(from.Bytes()[1:], 32),
+			common.LeftPadBytes(to.Bytes()[1:], 32),
+		},
+		Data: common.LeftPadBytes(big.NewInt(1000000).Bytes(), 32),
+	}
+
+	event, err := DecodeEvent(transferABI, log)
+	require.Nil(t, err)
+	assert.Equal(t, "Transfer", event.Name)
+	assert.Equal(t, "Transfer(address,address,uint256)", event.Signature)
+	require.Len(t, event.Params, 3)
+	assert.Equal(t, from.String(), event.Params[0].Value)
+	assert.Equal(t, to.String(), event.Params[1].Value)
+	assert.Equal(t, "1000000", event.Params[2].Value)
+
+	log.Topics[0] = make([]byte, 32)
+	_, err = DecodeEvent(transferABI, log)
+	assert.Equal(t, ErrEventNotFound, err)
+}
+
+func TestDecodeInput(t *testing.T) {
+	ABI := &core.SmartContract_ABI{
+		Entrys: []*core.SmartContract_ABI_Entry{{
+			Type: core.SmartContract_ABI_Entry_Function,
+			Name: "transfer",
+			Inputs: []*core.SmartContract_ABI_Entry_Param{
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+			},
+		}},
+	}
+	data, err := Pack("transfer(address,uint256)", []Param{
+		{"address": "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9"},
+		{"uint256": "1000000"},
+	})
+	require.Nil(t, err)
+
+	method, params, err := DecodeInput(ABI, data)
+	require.Nil(t, err)
+	assert.Equal(t, "transfer(address,uint256)", method)
+	require.Len(t, params, 2)
+	assert.Equal(t, "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9", params[0].Value)
+	assert.Equal(t, "1000000", params[1].Value)
+
+	_, _, err = DecodeInput(ABI, []byte{0x01, 0x02, 0x03, 0x04})
+	assert.Equal(t, ErrMethodNotFound, err)
+}
+
+func TestDecodeEventsOrder(t *testing.T) {
+	pingPongABI := &core.SmartContract_ABI{
+		Entrys: []*core.SmartContract_ABI_Entry{
+			{Type: core.SmartContract_ABI_Entry_Event, Name: "Pong"},
+			{Type: core.SmartContract_ABI_Entry_Event, Name: "Ping"},
+		},
+	}
+	ping := &core.TransactionInfo_Log{Topics: [][]byte{common.Keccak256([]byte("Ping()"))}}
+	pong := &core.TransactionInfo_Log{Topics: [][]byte{common.Keccak256([]byte("Pong()"))}}
+	unknown := &core.TransactionInfo_Log{Topics: [][]byte{common.Keccak256([]byte("Other()"))}}
+	info := &core.TransactionInfo{Log: []*core.TransactionInfo_Log{ping, unknown, pong, ping}}
+
+	events, err := DecodeEvents(pingPongABI, info)
+	require.Nil(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, "Ping", events[0].Name)
+	assert.Equal(t, 0, events[0].LogIndex)
+	assert.Equal(t, "Pong", events[1].Name)
+	assert.Equal(t, 2, events[1].LogIndex)
+	assert.Equal(t, "Ping", events[2].Name)
+	assert.Equal(t, 3, events[2].LogIndex)
+}
+
+func TestDecodeContractResult(t *testing.T) {
+	ABI := &core.SmartContract_ABI{
+		Entrys: []*core.SmartContract_ABI_Entry{{
+			Type: core.SmartContract_ABI_Entry_Function,
+			Name: "swap",
+			Inputs: []*core.SmartContract_ABI_Entry_Param{
+				{Name: "amountIn", Type: "uint256"},
+			},
+			Outputs: []*core.SmartContract_ABI_Entry_Param{
+				{Name: "amountOut", Type: "uint256"},
+				{Name: "to", Type: "address"},
+			},
+		}},
+	}
+	to, err := address.Base58ToAddress("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	require.Nil(t, err)
+	result := append(common.LeftPadBytes(big.NewInt(42).Bytes(), 32), common.LeftPadBytes(to.Bytes()[1:], 32)...)
+	info := &core.TransactionInfo{ContractResult: [][]byte{result}}
+
+	for _, method := range []string{"swap", "swap(uint256)"} {
+		params, err := DecodeContractResult(ABI, method, info)
+		require.Nil(t, err)
+		require.Len(t, params, 2)
+		assert.Equal(t, "amountOut", params[0].Name)
+		assert.Equal(t, "42", params[0].Value)
+		assert.Equal(t, to.String(), params[1].Value)
+	}
+
+	_, err = DecodeContractResult(ABI, "transfer", info)
+	assert.Equal(t, ErrMethodNotFound, err)
+
+	info.Result = core.TransactionInfo_FAILED
+	info.ResMessage = []byte("REVERT opcode executed")
+	_, err = DecodeContractResult(ABI, "swap", info)
+	assert.EqualError(t, err, "call failed: REVERT opcode executed")
+}
@@ -0,0 +1,146 @@
+package account
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/store"
+	"github.com/pborman/uuid"
+)
+
+// BatchKey freshly generated key, EncryptedKey is its keystore JSON and
+// PublicKey the uncompressed public key as HEX
+type BatchKey struct {
+	Address      string
+	PublicKey    string
+	EncryptedKey []byte
+
+	privateKey *ecdsa.PrivateKey
+}
+
+// GenerateKeys creates count keys on workers goroutines, each one encrypted
+// with passphrase using the standard scrypt parameters
+func GenerateKeys(count, workers int, passphrase string) ([]BatchKey, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("invalid count: %d", count)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	keys := make([]BatchKey, count)
+	errs := make([]error, count)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				keys[i], errs[i] = generateKey(passphrase)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func generateKey(passphrase string) (BatchKey, error) {
+	sk, err := btcec.NewPrivateKey()
+	if err != nil {
+		return BatchKey{}, err
+	}
+	key := &keystore.Key{
+		ID:         uuid.NewRandom(),
+		Address:    address.PubkeyToAddress(*sk.PubKey().ToECDSA()),
+		PrivateKey: sk.ToECDSA(),
+	}
+	encrypted, err := keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return BatchKey{}, err
+	}
+	return BatchKey{
+		Address:      key.Address.String(),
+		PublicKey:    hex.EncodeToString(sk.PubKey().SerializeUncompressed()),
+		EncryptedKey: encrypted,
+		privateKey:   key.PrivateKey,
+	}, nil
+}
+
+// BatchNames account names given by ImportBatchKeys, prefix_001 onwards
+// padded to three digits, e.g. batch_001 to batch_1000
+func BatchNames(prefix string, count int) []string {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s_%03d", prefix, i+1)
+	}
+	return names
+}
+
+// ImportBatchKeys imports keys generated by GenerateKeys into the local
+// keystore named as BatchNames, on workers goroutines as each import runs
+// scrypt again. None is imported if a name is taken, on failure the names
+// imported so far are returned with the first error.
+func ImportBatchKeys(keys []BatchKey, prefix, passphrase string, workers int) ([]string, error) {
+	names := BatchNames(prefix, len(keys))
+	for _, name := range names {
+		if store.DoesNamedAccountExist(name) {
+			return nil, fmt.Errorf("account %s already exists", name)
+		}
+	}
+	for _, key := range keys {
+		if key.privateKey == nil {
+			return nil, fmt.Errorf("key %s was not generated by GenerateKeys", key.Address)
+		}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	errs := make([]error, len(keys))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				ks := store.FromAccountName(names[i])
+				_, errs[i] = ks.ImportECDSA(keys[i].privateKey, passphrase)
+			}
+		}()
+	}
+	for i := range keys {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	imported := make([]string, 0, len(names))
+	var firstErr error
+	for i, err := range errs {
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %v", names[i], err)
+			}
+			continue
+		}
+		imported = append(imported, names[i])
+	}
+	return imported, firstErr
+}
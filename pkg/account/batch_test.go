@@ -0,0 +1,15 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchNames(t *testing.T) {
+	assert.Equal(t, []string{"batch_001", "batch_002"}, BatchNames("batch", 2))
+	names := BatchNames("batch", 1000)
+	assert.Equal(t, "batch_001", names[0])
+	assert.Equal(t, "batch_999", names[998])
+	assert.Equal(t, "batch_1000", names[999])
+}
@@ -1,6 +1,8 @@
 package account
 
 import (
+	"fmt"
+
 	"github.com/fbsobreira/gotron-sdk/pkg/keys"
 	"github.com/fbsobreira/gotron-sdk/pkg/mnemonic"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
@@ -14,6 +16,9 @@ type Creation struct {
 	MnemonicPassphrase string
 	HdAccountNumber    *uint32
 	HdIndexNumber      *uint32
+	// StoreMnemonic also keeps the mnemonic, encrypted with Passphrase, for
+	// show-mnemonic. Off by default, the mnemonic is then only shown once.
+	StoreMnemonic bool
 }
 
 // New create new name
@@ -39,5 +44,17 @@ func CreateNewLocalAccount(candidate *Creation) error {
 	if err != nil {
 		return err
 	}
+	if !candidate.StoreMnemonic {
+		return nil
+	}
+	// the mnemonic passphrase is not stored, it is needed along the mnemonic to recover keys
+	if err := store.StoreMnemonic(candidate.Name, candidate.Mnemonic, candidate.Passphrase); err != nil {
+		// leave no account behind rather than one without its mnemonic
+		if rmErr := RemoveAccount(candidate.Name); rmErr != nil {
+			return fmt.Errorf("store mnemonic: %v, account %s was created and could not be removed: %v",
+				err, candidate.Name, rmErr)
+		}
+		return fmt.Errorf("store mnemonic: %v, account not created", err)
+	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package account
 
 import (
+	"math/big"
+
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 )
 
@@ -49,3 +51,80 @@ type Account struct {
 	MaxCanDelegateBandwidth int64              `json:"maxCanDelegateBandwidth"`
 	MaxCanDelegateEnergy    int64              `json:"maxCanDelegateEnergy"`
 }
+
+// Token standards of a TokenBalance
+const (
+	TokenTRC10 = "TRC10"
+	TokenTRC20 = "TRC20"
+)
+
+// TokenBalance token held by an account, TokenID is the TRC10 asset ID or the
+// TRC20 contract address and Balance is in the token smallest unit
+type TokenBalance struct {
+	TokenID  string   `json:"tokenId"`
+	Symbol   string   `json:"symbol"`
+	Decimals int32    `json:"decimals"`
+	Balance  *big.Int `json:"balance"`
+	Type     string   `json:"type"`
+}
+
+// VoteEntry votes cast by an account for a witness
+type VoteEntry struct {
+	WitnessAddress string `json:"witnessAddress"`
+	VoteCount      int64  `json:"voteCount"`
+}
+
+// Resources account resource usage and network resource totals,
+// network weights are in TRX
+type Resources struct {
+	FreeNetUsed          int64            `json:"freeNetUsed"`
+	FreeNetLimit         int64            `json:"freeNetLimit"`
+	NetUsed              int64            `json:"netUsed"`
+	NetLimit             int64            `json:"netLimit"`
+	AssetNetUsed         map[string]int64 `json:"assetNetUsed"`
+	AssetNetLimit        map[string]int64 `json:"assetNetLimit"`
+	EnergyUsed           int64            `json:"energyUsed"`
+	EnergyLimit          int64            `json:"energyLimit"`
+	TronPowerUsed        int64            `json:"tronPowerUsed"`
+	TronPowerLimit       int64            `json:"tronPowerLimit"`
+	StorageUsed          int64            `json:"storageUsed"`
+	StorageLimit         int64            `json:"storageLimit"`
+	TotalNetLimit        int64            `json:"totalNetLimit"`
+	TotalNetWeight       int64            `json:"totalNetWeight"`
+	TotalEnergyLimit     int64            `json:"totalEnergyLimit"`
+	TotalEnergyWeight    int64            `json:"totalEnergyWeight"`
+	TotalTronPowerWeight int64            `json:"totalTronPowerWeight"`
+}
+
+// AvailableBandwidth free and staked bandwidth left
+func (r *Resources) AvailableBandwidth() int64 {
+	return max0(r.FreeNetLimit-r.FreeNetUsed) + max0(r.NetLimit-r.NetUsed)
+}
+
+// AvailableEnergy staked energy left
+func (r *Resources) AvailableEnergy() int64 {
+	return max0(r.EnergyLimit - r.EnergyUsed)
+}
+
+// BandwidthForStake bandwidth obtained by staking amount SUN at current network weight
+func (r *Resources) BandwidthForStake(amount int64) int64 {
+	if r.TotalNetWeight == 0 {
+		return 0
+	}
+	return int64(float64(amount) / 1000000 * float64(r.TotalNetLimit) / float64(r.TotalNetWeight))
+}
+
+// EnergyForStake energy obtained by staking amount SUN at current network weight
+func (r *Resources) EnergyForStake(amount int64) int64 {
+	if r.TotalEnergyWeight == 0 {
+		return 0
+	}
+	return int64(float64(amount) / 1000000 * float64(r.TotalEnergyLimit) / float64(r.TotalEnergyWeight))
+}
+
+func max0(v int64) int64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,88 @@
+package account
+
+import (
+	"sort"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// PermissionKey signer of a permission
+type PermissionKey struct {
+	Address string `json:"address"`
+	Weight  int64  `json:"weight"`
+}
+
+// Permission readable account permission, Operations names the contract
+// types an active permission allows, as accepted by UpdateAccountPermission
+type Permission struct {
+	ID         int32           `json:"id"`
+	Type       string          `json:"type"`
+	Name       string          `json:"name"`
+	Threshold  int64           `json:"threshold"`
+	Keys       []PermissionKey `json:"keys"`
+	Operations []string        `json:"operations,omitempty"`
+}
+
+// Permissions of an account, Witness is only set for witness accounts
+type Permissions struct {
+	Owner   *Permission  `json:"owner"`
+	Witness *Permission  `json:"witness,omitempty"`
+	Actives []Permission `json:"actives"`
+}
+
+// DecodePermissions converts the permissions returned by GetAccount
+func DecodePermissions(acc *core.Account) *Permissions {
+	permissions := &Permissions{
+		Actives: make([]Permission, 0, len(acc.GetActivePermission())),
+	}
+	if acc.GetOwnerPermission() != nil {
+		owner := decodePermission(acc.GetOwnerPermission())
+		permissions.Owner = &owner
+	}
+	if acc.GetWitnessPermission() != nil {
+		witness := decodePermission(acc.GetWitnessPermission())
+		permissions.Witness = &witness
+	}
+	for _, p := range acc.GetActivePermission() {
+		permissions.Actives = append(permissions.Actives, decodePermission(p))
+	}
+	return permissions
+}
+
+func decodePermission(p *core.Permission) Permission {
+	permission := Permission{
+		ID:        p.GetId(),
+		Type:      p.GetType().String(),
+		Name:      p.GetPermissionName(),
+		Threshold: p.GetThreshold(),
+		Keys:      make([]PermissionKey, 0, len(p.GetKeys())),
+	}
+	for _, k := range p.GetKeys() {
+		permission.Keys = append(permission.Keys, PermissionKey{
+			Address: address.Address(k.GetAddress()).String(),
+			Weight:  k.GetWeight(),
+		})
+	}
+	if p.GetType() == core.Permission_Active {
+		permission.Operations = DecodeOperations(p.GetOperations())
+	}
+	return permission
+}
+
+// DecodeOperations returns the contract type names set in an active
+// permission operations bitmap, bit n of byte n/8 being contract type n
+func DecodeOperations(operations []byte) []string {
+	names := make([]string, 0)
+	types := make([]int, 0, len(core.Transaction_Contract_ContractType_name))
+	for t := range core.Transaction_Contract_ContractType_name {
+		types = append(types, int(t))
+	}
+	sort.Ints(types)
+	for _, t := range types {
+		if t/8 < len(operations) && operations[t/8]&(1<<uint(t%8)) != 0 {
+			names = append(names, core.Transaction_Contract_ContractType_name[int32(t)])
+		}
+	}
+	return names
+}
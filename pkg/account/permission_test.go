@@ -0,0 +1,47 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodePermissions(t *testing.T) {
+	signer, err := address.Base58ToAddress("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	require.Nil(t, err)
+
+	// TransferContract (1) and TriggerSmartContract (31)
+	operations := make([]byte, 32)
+	operations[0] = 0x02
+	operations[3] = 0x80
+	acc := &core.Account{
+		OwnerPermission: &core.Permission{
+			Type:           core.Permission_Owner,
+			PermissionName: "owner",
+			Threshold:      1,
+			Keys:           []*core.Key{{Address: signer, Weight: 1}},
+		},
+		ActivePermission: []*core.Permission{{
+			Type:           core.Permission_Active,
+			Id:             2,
+			PermissionName: "payments",
+			Threshold:      2,
+			Operations:     operations,
+			Keys:           []*core.Key{{Address: signer, Weight: 2}},
+		}},
+	}
+
+	permissions := DecodePermissions(acc)
+	require.NotNil(t, permissions.Owner)
+	assert.Nil(t, permissions.Witness)
+	assert.Equal(t, "Owner", permissions.Owner.Type)
+	assert.Empty(t, permissions.Owner.Operations)
+	assert.Equal(t, []PermissionKey{{Address: "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", Weight: 1}}, permissions.Owner.Keys)
+
+	require.Len(t, permissions.Actives, 1)
+	assert.Equal(t, int32(2), permissions.Actives[0].ID)
+	assert.Equal(t, []string{"TransferContract", "TriggerSmartContract"}, permissions.Actives[0].Operations)
+}
@@ -23,5 +23,5 @@ func RemoveAccount(name string) error {
 	accountDir := fmt.Sprintf("%s/%s", tronCTLDir, name)
 	os.RemoveAll(accountDir)
 
-	return nil
+	return store.RemoveMnemonic(name)
 }
@@ -111,3 +111,64 @@ func (a *Address) Scan(src interface{}) error {
 func (a Address) Value() (driver.Value, error) {
 	return []byte(a), nil
 }
+
+// ComputeContractAddress returns the address a CreateSmartContract transaction
+// deploys to, derived as the node does from the transaction ID and the
+// deployer address: keccak256(txID || deployer) with the first 12 bytes
+// replaced by the Tron prefix
+func ComputeContractAddress(deployer Address, txID []byte) Address {
+	combined := make([]byte, 0, len(txID)+len(deployer))
+	combined = append(combined, txID...)
+	combined = append(combined, deployer...)
+	hash := common.Keccak256(combined)
+
+	contract := make([]byte, 0, AddressLength)
+	contract = append(contract, TronBytePrefix)
+	contract = append(contract, hash[12:]...)
+	return contract
+}
+
+// Equal reports whether a and b are the same account. Each can be an
+// Address, raw bytes or a string in base58 or HEX, with or without the 0x41
+// prefix. Values that are not valid addresses are never equal.
+func Equal(a, b interface{}) bool {
+	addrA, err := normalize(a)
+	if err != nil {
+		return false
+	}
+	addrB, err := normalize(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(addrA, addrB)
+}
+
+// normalize converts any supported representation to the 21 byte form
+func normalize(v interface{}) (Address, error) {
+	var raw []byte
+	switch value := v.(type) {
+	case Address:
+		raw = value
+	case []byte:
+		raw = value
+	case string:
+		if len(value) == AddressLengthBase58 {
+			return Base58ToAddress(value)
+		}
+		decoded, err := common.FromHex(value)
+		if err != nil {
+			return nil, err
+		}
+		raw = decoded
+	default:
+		return nil, fmt.Errorf("unsupported address type %T", v)
+	}
+
+	switch {
+	case len(raw) == AddressLength && raw[0] == TronBytePrefix:
+		return Address(raw), nil
+	case len(raw) == AddressLength-1:
+		return append(Address{TronBytePrefix}, raw...), nil
+	}
+	return nil, fmt.Errorf("invalid address length: %d", len(raw))
+}
@@ -2,6 +2,7 @@ package address
 
 import (
 	"bytes"
+	"encoding/hex"
 	"testing"
 )
 
@@ -43,3 +44,52 @@ func TestAddress_Scan(t *testing.T) {
 		t.Errorf("expected an error, but got none")
 	}
 }
+
+func TestEqual(t *testing.T) {
+	base58 := "TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1"
+	addr, err := Base58ToAddress(base58)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hex := addr.Hex()
+
+	same := []interface{}{base58, addr, addr.Bytes(), hex, hex[2:], "0x" + hex[4:], addr.Bytes()[1:]}
+	for _, a := range same {
+		for _, b := range same {
+			if !Equal(a, b) {
+				t.Errorf("expected %v and %v to be equal", a, b)
+			}
+		}
+	}
+
+	other := "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9"
+	if Equal(base58, other) {
+		t.Errorf("expected %s and %s to differ", base58, other)
+	}
+	for _, invalid := range []interface{}{"", "not an address", make([]byte, 4), 42, nil} {
+		if Equal(invalid, invalid) {
+			t.Errorf("expected invalid %v to never be equal", invalid)
+		}
+	}
+}
+
+func TestComputeContractAddress(t *testing.T) {
+	deployer, err := Base58ToAddress("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	txID, err := hex.DecodeString("5ce6d5e2b7d1c0d5f9a3d5b4e8a2c1f0e9d8c7b6a5f4e3d2c1b0a99887766554")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// java-tron Hash.sha3omit12(txID || deployer): the last 21 bytes of the
+	// keccak256 hash with the first one replaced by the 0x41 prefix
+	got := ComputeContractAddress(deployer, txID)
+	if want := "TQdzTRmY6wjcpk89XmM5fPJybSVn1VeoT2"; got.String() != want {
+		t.Errorf("got %s, want %s", got.String(), want)
+	}
+	if want := "0x41a0e782da0bf845287b8a9b5c09a768cf3afc440e"; got.Hex() != want {
+		t.Errorf("got %s, want %s", got.Hex(), want)
+	}
+}
@@ -0,0 +1,148 @@
+// Package circuitbreaker stops calls to a failing dependency for a while
+// instead of letting callers keep retrying it
+package circuitbreaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitState of a Breaker
+type CircuitState int
+
+const (
+	// Closed lets every call through
+	Closed CircuitState = iota
+	// Open rejects calls with ErrCircuitOpen
+	Open
+	// HalfOpen lets a single trial call through to decide whether to close
+	HalfOpen
+)
+
+// String name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("state(%d)", int(s))
+}
+
+// Defaults used by New when zero values are given
+const (
+	DefaultMaxFailures  = 5
+	DefaultOpenDuration = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned instead of calling while the breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Breaker opens after MaxFailures consecutive failures and rejects calls for
+// OpenDuration, then lets one trial call decide whether to close again
+type Breaker struct {
+	maxFailures  int
+	openDuration time.Duration
+	// IsFailure tells which errors count as failures, all of them when nil
+	IsFailure func(error) bool
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool
+	now      func() time.Time
+}
+
+// New initializes a closed Breaker, zero values select the defaults
+func New(maxFailures int, openDuration time.Duration) *Breaker {
+	if maxFailures <= 0 {
+		maxFailures = DefaultMaxFailures
+	}
+	if openDuration <= 0 {
+		openDuration = DefaultOpenDuration
+	}
+	return &Breaker{
+		maxFailures:  maxFailures,
+		openDuration: openDuration,
+		now:          time.Now,
+	}
+}
+
+// State returns the current state, Open turns HalfOpen once OpenDuration passed
+func (b *Breaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	return b.state
+}
+
+// advance moves an expired Open state to HalfOpen, mu must be held
+func (b *Breaker) advance() {
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.openDuration {
+		b.state = HalfOpen
+		b.trial = false
+	}
+}
+
+// Allow returns ErrCircuitOpen when the call must not be made, otherwise the
+// caller makes it and reports the outcome with Record
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	switch b.state {
+	case Open:
+		return ErrCircuitOpen
+	case HalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// Record reports the outcome of a call allowed by Allow
+func (b *Breaker) Record(err error) {
+	failed := err != nil && (b.IsFailure == nil || b.IsFailure(err))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		b.state = Closed
+		b.failures = 0
+		b.trial = false
+		return
+	}
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.maxFailures {
+		b.state = Open
+		b.openedAt = b.now()
+		b.trial = false
+	}
+}
+
+// Execute runs fn unless the breaker is open and records its outcome
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(err)
+	return err
+}
+
+// Reset closes the breaker and clears the failure count
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = Closed
+	b.failures = 0
+	b.trial = false
+}
@@ -0,0 +1,52 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := New(3, time.Minute)
+	b.now = func() time.Time { return now }
+	failure := errors.New("unavailable")
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, failure, b.Execute(func() error { return failure }))
+	}
+	assert.Equal(t, Closed, b.State())
+	// a success resets the consecutive failure count
+	assert.Nil(t, b.Execute(func() error { return nil }))
+	for i := 0; i < 3; i++ {
+		b.Execute(func() error { return failure })
+	}
+	assert.Equal(t, Open, b.State())
+
+	called := false
+	assert.Equal(t, ErrCircuitOpen, b.Execute(func() error { called = true; return nil }))
+	assert.False(t, called)
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, HalfOpen, b.State())
+	assert.Nil(t, b.Allow())
+	// only one trial call at a time
+	assert.Equal(t, ErrCircuitOpen, b.Allow())
+	b.Record(failure)
+	assert.Equal(t, Open, b.State())
+
+	now = now.Add(time.Minute)
+	assert.Nil(t, b.Execute(func() error { return nil }))
+	assert.Equal(t, Closed, b.State())
+}
+
+func TestBreakerIsFailure(t *testing.T) {
+	b := New(1, time.Minute)
+	b.IsFailure = func(err error) bool { return err.Error() == "unavailable" }
+	b.Execute(func() error { return errors.New("not found") })
+	assert.Equal(t, Closed, b.State())
+	b.Execute(func() error { return errors.New("unavailable") })
+	assert.Equal(t, Open, b.State())
+}
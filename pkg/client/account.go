@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
+	"sort"
 	"time"
 
 	"github.com/fbsobreira/gotron-sdk/pkg/account"
@@ -14,6 +15,9 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrAccountNotFound is returned when the address has not been activated on chain
+var ErrAccountNotFound = fmt.Errorf("account not found")
+
 // GetAccount from BASE58 address
 func (g *GrpcClient) GetAccount(addr string) (*core.Account, error) {
 	account := new(core.Account)
@@ -32,11 +36,63 @@ func (g *GrpcClient) GetAccount(addr string) (*core.Account, error) {
 		return nil, err
 	}
 	if !bytes.Equal(acc.Address, account.Address) {
-		return nil, fmt.Errorf("account not found")
+		return nil, ErrAccountNotFound
 	}
 	return acc, nil
 }
 
+// GetAccountPermissions from BASE58 address, with active permission
+// operations decoded into contract type names
+func (g *GrpcClient) GetAccountPermissions(addr string) (*account.Permissions, error) {
+	acc, err := g.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+	return account.DecodePermissions(acc), nil
+}
+
+// GetVoteList returns the votes cast by addr, most voted witness first
+func (g *GrpcClient) GetVoteList(addr string) ([]*account.VoteEntry, error) {
+	acc, err := g.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+	votes := make([]*account.VoteEntry, 0, len(acc.GetVotes()))
+	for _, vote := range acc.GetVotes() {
+		votes = append(votes, &account.VoteEntry{
+			WitnessAddress: address.Address(vote.GetVoteAddress()).String(),
+			VoteCount:      vote.GetVoteCount(),
+		})
+	}
+	sort.SliceStable(votes, func(i, j int) bool {
+		return votes[i].VoteCount > votes[j].VoteCount
+	})
+	return votes, nil
+}
+
+// GetVotingPower returns the voting power, in TRX, used by the votes of addr
+// and the total it can vote with
+func (g *GrpcClient) GetVotingPower(addr string) (used, available int64, err error) {
+	res, err := g.GetAccountResource(addr)
+	if err != nil {
+		return 0, 0, err
+	}
+	return res.GetTronPowerUsed(), res.GetTronPowerLimit(), nil
+}
+
+// GetAccountCreationTime from BASE58 address, false is returned for
+// addresses not yet activated
+func (g *GrpcClient) GetAccountCreationTime(addr string) (time.Time, bool, error) {
+	acc, err := g.GetAccount(addr)
+	if err == ErrAccountNotFound {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return time.UnixMilli(acc.GetCreateTime()), true, nil
+}
+
 // GetRewardsInfo from BASE58 address
 func (g *GrpcClient) GetRewardsInfo(addr string) (int64, error) {
 	addrBytes, err := common.DecodeCheck(addr)
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// bandwidthSignatureSize bytes added by each signature: 65 of r, s and v plus
+// the field tag and length
+const bandwidthSignatureSize = 67
+
+// bandwidthResultSize bytes reserved by the node for the result of each contract
+const bandwidthResultSize = 64
+
+// defaultBandwidthPrice SUN burned per byte when getTransactionFee is missing
+const defaultBandwidthPrice = 1000
+
+// BandwidthCost bandwidth a transaction consumes and how it is paid
+type BandwidthCost struct {
+	// Bytes bandwidth points charged for the transaction
+	Bytes int64
+	// Free is set when staked or daily free bandwidth covers Bytes
+	Free bool
+	// Fee SUN burned when not Free
+	Fee int64
+}
+
+// EstimateBandwidth bytes charged for tx once signed, signatures not yet
+// present are counted as one per owner
+func EstimateBandwidth(tx *core.Transaction) int64 {
+	size := int64(proto.Size(tx))
+	if len(tx.GetSignature()) == 0 {
+		size += bandwidthSignatureSize
+	}
+	return size + bandwidthResultSize*int64(len(tx.GetRawData().GetContract()))
+}
+
+// EstimateBandwidthCost reports whether addr pays the bandwidth of tx with
+// its staked or daily free bandwidth, or the TRX that would be burned. Fees of
+// account activation and of energy used by contracts are not included.
+func (g *GrpcClient) EstimateBandwidthCost(addr string, tx *core.Transaction) (*BandwidthCost, error) {
+	net, err := g.GetAccountNet(addr)
+	if err != nil {
+		return nil, err
+	}
+	params, err := g.ChainParametersSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return bandwidthCost(net, EstimateBandwidth(tx), params["getTransactionFee"]), nil
+}
+
+// bandwidthCost mirrors the node: staked bandwidth is used first, then the
+// free daily allowance, otherwise all bytes are paid in SUN
+func bandwidthCost(net *api.AccountNetMessage, bytes, price int64) *BandwidthCost {
+	if price == 0 {
+		price = defaultBandwidthPrice
+	}
+	cost := &BandwidthCost{Bytes: bytes}
+	if net.GetNetLimit()-net.GetNetUsed() >= bytes ||
+		net.GetFreeNetLimit()-net.GetFreeNetUsed() >= bytes {
+		cost.Free = true
+		return cost
+	}
+	cost.Fee = bytes * price
+	return cost
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBandwidthCost(t *testing.T) {
+	net := &api.AccountNetMessage{FreeNetUsed: 400, FreeNetLimit: 600}
+	assert.Equal(t, &BandwidthCost{Bytes: 200, Free: true}, bandwidthCost(net, 200, 1000))
+	assert.Equal(t, &BandwidthCost{Bytes: 268, Fee: 268000}, bandwidthCost(net, 268, 1000))
+	assert.Equal(t, &BandwidthCost{Bytes: 268, Fee: 268000}, bandwidthCost(net, 268, 0))
+
+	net.NetLimit = 1000
+	assert.Equal(t, &BandwidthCost{Bytes: 268, Free: true}, bandwidthCost(net, 268, 1000))
+}
+
+func TestEstimateBandwidth(t *testing.T) {
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		Contract:   []*core.Transaction_Contract{{Type: core.Transaction_Contract_TransferContract}},
+		Expiration: 1700000060000,
+	}}
+	unsigned := int64(proto.Size(tx))
+	assert.Equal(t, unsigned+67+64, EstimateBandwidth(tx))
+
+	tx.Signature = [][]byte{make([]byte, 65)}
+	assert.Equal(t, unsigned+67+64, EstimateBandwidth(tx))
+}
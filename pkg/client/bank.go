@@ -12,18 +12,30 @@ import (
 // FreezeBalance from base58 address
 func (g *GrpcClient) FreezeBalance(from, delegateTo string,
 	resource core.ResourceCode, frozenBalance int64) (*api.TransactionExtention, error) {
+	return g.LegacyFreezeBalance(from, frozenBalance, 3, resource, delegateTo) // Tron Only allows 3 days freeze
+}
+
+// LegacyFreezeBalance freezes with the deprecated Stake 1.0 contract,
+// delegating the resource to receiverAddr when not empty. Networks that
+// enabled Stake 2.0 reject it, use FreezeBalanceV2 and DelegateResource.
+func (g *GrpcClient) LegacyFreezeBalance(ownerAddr string, frozenBalance int64, frozenDuration int,
+	resource core.ResourceCode, receiverAddr string) (*api.TransactionExtention, error) {
 	var err error
 
+	if frozenDuration <= 0 {
+		return nil, fmt.Errorf("invalid frozen duration: %d", frozenDuration)
+	}
+
 	contract := &core.FreezeBalanceContract{}
-	if contract.OwnerAddress, err = common.DecodeCheck(from); err != nil {
+	if contract.OwnerAddress, err = common.DecodeCheck(ownerAddr); err != nil {
 		return nil, err
 	}
 
 	contract.FrozenBalance = frozenBalance
-	contract.FrozenDuration = 3 // Tron Only allows 3 days freeze
+	contract.FrozenDuration = int64(frozenDuration)
 
-	if len(delegateTo) > 0 {
-		if contract.ReceiverAddress, err = common.DecodeCheck(delegateTo); err != nil {
+	if len(receiverAddr) > 0 {
+		if contract.ReceiverAddress, err = common.DecodeCheck(receiverAddr); err != nil {
 			return nil, err
 		}
 
@@ -108,6 +120,13 @@ func (g *GrpcClient) UnfreezeBalance(from, delegateTo string, resource core.Reso
 	return tx, nil
 }
 
+// LegacyUnfreezeBalance unfreezes a Stake 1.0 balance, frozen by the owner
+// for receiverAddr when not empty. Balances staked with FreezeBalanceV2 are
+// released with UnfreezeBalanceV2 instead.
+func (g *GrpcClient) LegacyUnfreezeBalance(ownerAddr string, resource core.ResourceCode, receiverAddr string) (*api.TransactionExtention, error) {
+	return g.UnfreezeBalance(ownerAddr, receiverAddr, resource)
+}
+
 // UnfreezeBalance from base58 address
 func (g *GrpcClient) UnfreezeBalanceV2(from string, resource core.ResourceCode, unfreezeBalance int64) (*api.TransactionExtention, error) {
 	var err error
@@ -136,6 +155,32 @@ func (g *GrpcClient) UnfreezeBalanceV2(from string, resource core.ResourceCode,
 	return tx, nil
 }
 
+// CancelAllUnfreezeV2 cancels every pending UnfreezeBalanceV2 of from, amounts
+// still in their waiting period return to stake and expired ones are withdrawn
+func (g *GrpcClient) CancelAllUnfreezeV2(from string) (*api.TransactionExtention, error) {
+	var err error
+
+	contract := &core.CancelAllUnfreezeV2Contract{}
+	if contract.OwnerAddress, err = common.DecodeCheck(from); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	tx, err := g.Client.CancelAllUnfreezeV2(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	if proto.Size(tx) == 0 {
+		return nil, fmt.Errorf("bad transaction")
+	}
+	if tx.GetResult().GetCode() != 0 {
+		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+	return tx, nil
+}
+
 // GetAvailableUnfreezeCount from base58 address
 func (g *GrpcClient) GetAvailableUnfreezeCount(from string) (*api.GetAvailableUnfreezeCountResponseMessage, error) {
 	var err error
@@ -1,12 +1,16 @@
 package client
 
 import (
+	"crypto/sha256"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 // GetNowBlock return TIP block
@@ -100,3 +104,36 @@ func (g *GrpcClient) GetBlockByLatestNum(num int64) (*api.BlockListExtention, er
 	maxSizeOption := grpc.MaxCallRecvMsgSize(32 * 10e6)
 	return g.Client.GetBlockByLatestNum2(ctx, numMessage, maxSizeOption)
 }
+
+// GetBlockSignature returns the base58 address that signed the block header
+func (g *GrpcClient) GetBlockSignature(num int64) (string, error) {
+	block, err := g.GetBlockByNum(num)
+	if err != nil {
+		return "", err
+	}
+	return RecoverBlockSigner(block)
+}
+
+// RecoverBlockSigner recovers the base58 signer address from the block
+// witness signature
+func RecoverBlockSigner(block *api.BlockExtention) (string, error) {
+	header := block.GetBlockHeader()
+	if header.GetRawData() == nil || len(header.GetWitnessSignature()) != 65 {
+		return "", fmt.Errorf("block has no valid witness signature")
+	}
+	rawData, err := proto.Marshal(header.GetRawData())
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(rawData)
+
+	signature := common.CopyBytes(header.GetWitnessSignature())
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	pubKey, err := crypto.SigToPub(hash[:], signature)
+	if err != nil {
+		return "", err
+	}
+	return address.PubkeyToAddress(*pubKey).String(), nil
+}
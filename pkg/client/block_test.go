@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// signedBlock header signed by the witness TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH
+func signedBlock(t *testing.T) *api.BlockExtention {
+	witness, err := common.DecodeCheck("TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH")
+	require.Nil(t, err)
+	parent, err := hex.DecodeString("0000000003b5a2f08c2f1f5a7e6d4c3b2a19080706050403020100ffeeddccbb")
+	require.Nil(t, err)
+	signature, err := hex.DecodeString("39eff774352e9e6282f9ec946cd71dabebff70c166011e2b1bbd9f675cc60a79" +
+		"04efa2026131ca0d0d4ea06722f20df45e750fec08a0aafc82316601270cc6c601")
+	require.Nil(t, err)
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{
+		RawData: &core.BlockHeaderRaw{
+			Timestamp:      1700000000000,
+			Number:         62235377,
+			WitnessAddress: witness,
+			ParentHash:     parent,
+			Version:        30,
+		},
+		WitnessSignature: signature,
+	}}
+}
+
+// blockWallet serves block
+type blockWallet struct {
+	api.WalletClient
+	block *api.BlockExtention
+}
+
+func (w *blockWallet) GetBlockByNum2(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return w.block, nil
+}
+
+func TestRecoverBlockSigner(t *testing.T) {
+	block := signedBlock(t)
+	signer, err := client.RecoverBlockSigner(block)
+	require.Nil(t, err)
+	assert.Equal(t, "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", signer)
+
+	// recovery ID in the 27/28 form
+	block.BlockHeader.WitnessSignature[64] += 27
+	signer, err = client.RecoverBlockSigner(block)
+	require.Nil(t, err)
+	assert.Equal(t, "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", signer)
+	assert.Equal(t, byte(28), block.BlockHeader.WitnessSignature[64])
+
+	// any change to the header yields another signer
+	block.BlockHeader.RawData.Number++
+	signer, err = client.RecoverBlockSigner(block)
+	require.Nil(t, err)
+	assert.NotEqual(t, "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", signer)
+
+	block.BlockHeader.WitnessSignature = nil
+	_, err = client.RecoverBlockSigner(block)
+	assert.NotNil(t, err)
+}
+
+func TestGetBlockSignature(t *testing.T) {
+	c := client.NewGrpcClient("")
+	c.Client = &blockWallet{block: signedBlock(t)}
+	signer, err := c.GetBlockSignature(62235377)
+	require.Nil(t, err)
+	assert.Equal(t, "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", signer)
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/circuitbreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewNodeBreaker returns a breaker with default settings counting only errors
+// that show the node is unreachable or not answering, for SetCircuitBreaker
+func NewNodeBreaker() *circuitbreaker.Breaker {
+	b := circuitbreaker.New(circuitbreaker.DefaultMaxFailures, circuitbreaker.DefaultOpenDuration)
+	b.IsFailure = nodeUnreachable
+	return b
+}
+
+func nodeUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// SetCircuitBreaker guards calls to the node with b, e.g. NewNodeBreaker(),
+// calls fail with circuitbreaker.ErrCircuitOpen while it is open. Clients
+// have none by default, nil disables it again.
+func (g *GrpcClient) SetCircuitBreaker(b *circuitbreaker.Breaker) {
+	g.breaker.Store(b)
+}
+
+// CircuitBreakerState state of the breaker guarding calls to the node,
+// always Closed when disabled
+func (g *GrpcClient) CircuitBreakerState() circuitbreaker.CircuitState {
+	b := g.breaker.Load()
+	if b == nil {
+		return circuitbreaker.Closed
+	}
+	return b.State()
+}
+
+func (g *GrpcClient) breakerInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	b := g.breaker.Load()
+	if b == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	return b.Execute(func() error {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
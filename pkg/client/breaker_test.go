@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreakerInterceptor(t *testing.T) {
+	failing := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+	call := func(g *GrpcClient) error {
+		return g.breakerInterceptor(context.Background(), "/protocol.Wallet/GetNowBlock2", nil, nil, nil, failing)
+	}
+
+	// disabled unless set
+	g := NewGrpcClient("")
+	for i := 0; i < 2*circuitbreaker.DefaultMaxFailures; i++ {
+		assert.Equal(t, codes.Unavailable, status.Code(call(g)))
+	}
+	assert.Equal(t, circuitbreaker.Closed, g.CircuitBreakerState())
+
+	g.SetCircuitBreaker(NewNodeBreaker())
+	for i := 0; i < circuitbreaker.DefaultMaxFailures; i++ {
+		assert.Equal(t, codes.Unavailable, status.Code(call(g)))
+	}
+	assert.Equal(t, circuitbreaker.Open, g.CircuitBreakerState())
+	assert.True(t, errors.Is(call(g), circuitbreaker.ErrCircuitOpen))
+}
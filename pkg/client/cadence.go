@@ -0,0 +1,41 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+)
+
+// cadenceSampleSize latest blocks read by EstimateConfirmationTime
+const cadenceSampleSize = 20
+
+// EstimateConfirmationTime samples the latest blocks and returns the average
+// interval between them and the interval before the head block, the expected
+// wait for a broadcast transaction to be included
+func (g *GrpcClient) EstimateConfirmationTime() (time.Duration, time.Duration, error) {
+	list, err := g.GetBlockByLatestNum(cadenceSampleSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	return blockCadence(list.GetBlock())
+}
+
+// blockCadence average and latest interval between blocks, in any order
+func blockCadence(blocks []*api.BlockExtention) (time.Duration, time.Duration, error) {
+	if len(blocks) < 2 {
+		return 0, 0, fmt.Errorf("at least 2 blocks needed, got %d", len(blocks))
+	}
+	sorted := append([]*api.BlockExtention{}, blocks...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetBlockHeader().GetRawData().GetNumber() < sorted[j].GetBlockHeader().GetRawData().GetNumber()
+	})
+	timestamp := func(i int) int64 {
+		return sorted[i].GetBlockHeader().GetRawData().GetTimestamp()
+	}
+	n := len(sorted) - 1
+	average := time.Duration(timestamp(n)-timestamp(0)) * time.Millisecond / time.Duration(n)
+	last := time.Duration(timestamp(n)-timestamp(n-1)) * time.Millisecond
+	return average, last, nil
+}
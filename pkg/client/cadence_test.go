@@ -0,0 +1,33 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCadence(t *testing.T) {
+	block := func(number, timestamp int64) *api.BlockExtention {
+		return &api.BlockExtention{BlockHeader: &core.BlockHeader{
+			RawData: &core.BlockHeaderRaw{Number: number, Timestamp: timestamp},
+		}}
+	}
+	// a missed slot between 101 and 102
+	blocks := []*api.BlockExtention{
+		block(102, 1700000009000),
+		block(100, 1700000000000),
+		block(103, 1700000012000),
+		block(101, 1700000003000),
+	}
+	average, last, err := blockCadence(blocks)
+	require.Nil(t, err)
+	assert.Equal(t, 4*time.Second, average)
+	assert.Equal(t, 3*time.Second, last)
+
+	_, _, err = blockCadence(blocks[:1])
+	assert.Error(t, err)
+}
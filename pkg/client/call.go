@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// ErrNoABI is returned by CallContract when the contract was deployed without
+// ABI, the caller has to supply one
+var ErrNoABI = errors.New("contract has no ABI on chain")
+
+// CallContract runs a constant call of method, a name like "balanceOf", and
+// returns its decoded outputs. args follow the method inputs in the format
+// accepted by TriggerConstantContract params, e.g. base58 strings for
+// addresses and decimal strings for integers. A nil ABI is fetched from the
+// chain once per contract and cached for the client lifetime.
+func (g *GrpcClient) CallContract(from, contractAddress string, ABI *core.SmartContract_ABI,
+	method string, args ...interface{}) ([]interface{}, error) {
+	var err error
+	if ABI == nil {
+		if ABI, err = g.cachedContractABI(contractAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	var entry *core.SmartContract_ABI_Entry
+	for _, e := range ABI.GetEntrys() {
+		if e.Type == core.SmartContract_ABI_Entry_Function && e.Name == method && len(e.Inputs) == len(args) {
+			entry = e
+			break
+		}
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("method %s with %d argument(s) not found in ABI", method, len(args))
+	}
+
+	param := make([]abi.Param, len(args))
+	for i, input := range entry.Inputs {
+		param[i] = abi.Param{input.Type: args[i]}
+	}
+	data, err := abi.Pack(abi.EntrySignature(entry), param)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDesc := address.HexToAddress("410000000000000000000000000000000000000000")
+	if len(from) > 0 {
+		if fromDesc, err = address.Base58ToAddress(from); err != nil {
+			return nil, err
+		}
+	}
+	contractDesc, err := address.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := g.triggerConstantContract(&core.TriggerSmartContract{
+		OwnerAddress:    fromDesc.Bytes(),
+		ContractAddress: contractDesc.Bytes(),
+		Data:            data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tx.Result.Code > 0 {
+		return nil, fmt.Errorf("%s", string(tx.Result.Message))
+	}
+	if len(tx.GetConstantResult()) == 0 {
+		return nil, fmt.Errorf("no result from %s", method)
+	}
+
+	outputs := eABI.Arguments{}
+	for _, out := range entry.Outputs {
+		ty, err := eABI.NewType(out.Type, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid param %s: %+v", out.Type, err)
+		}
+		outputs = append(outputs, eABI.Argument{Name: out.Name, Type: ty})
+	}
+	return outputs.Unpack(tx.GetConstantResult()[0])
+}
+
+// cachedContractABI returns the on-chain ABI of contractAddress, fetched once
+func (g *GrpcClient) cachedContractABI(contractAddress string) (*core.SmartContract_ABI, error) {
+	if cached, ok := g.abiCache.Load(contractAddress); ok {
+		return cached.(*core.SmartContract_ABI), nil
+	}
+	ABI, err := g.GetContractABI(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(ABI.GetEntrys()) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoABI, contractAddress)
+	}
+	g.abiCache.Store(contractAddress, ABI)
+	return ABI, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrWrongNetwork is returned by Broadcast when the node chain ID differs
+// from the one set with SetExpectedChainID
+var ErrWrongNetwork = errors.New("connected to the wrong network")
+
+// GetChainID returns the network chain ID, the last 4 bytes of the genesis
+// block hash as used by TIP-712. It is read once per client.
+func (g *GrpcClient) GetChainID() (uint32, error) {
+	if id := g.chainID.Load(); id != 0 {
+		return id, nil
+	}
+	genesis, err := g.GetBlockByNum(0)
+	if err != nil {
+		return 0, err
+	}
+	id, err := chainIDFromBlockID(genesis.GetBlockid())
+	if err != nil {
+		return 0, err
+	}
+	g.chainID.Store(id)
+	return id, nil
+}
+
+// GetChainIDHex returns GetChainID as 0x prefixed HEX, e.g. 0x2b6653dc on mainnet
+func (g *GrpcClient) GetChainIDHex() (string, error) {
+	id, err := g.GetChainID()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("0x%08x", id), nil
+}
+
+// SetExpectedChainID makes Broadcast verify the node serves the network with
+// the given chain ID and fail with ErrWrongNetwork otherwise, transactions
+// meant for another network are never sent. Zero, the default, disables it.
+func (g *GrpcClient) SetExpectedChainID(id uint32) {
+	g.expectedChainID = id
+}
+
+// checkNetwork fails when the node chain ID is not the expected one
+func (g *GrpcClient) checkNetwork() error {
+	if g.expectedChainID == 0 {
+		return nil
+	}
+	id, err := g.GetChainID()
+	if err != nil {
+		return fmt.Errorf("cannot verify network: %w", err)
+	}
+	if id != g.expectedChainID {
+		return fmt.Errorf("%w: node chain ID 0x%08x, expected 0x%08x", ErrWrongNetwork, id, g.expectedChainID)
+	}
+	return nil
+}
+
+func chainIDFromBlockID(blockID []byte) (uint32, error) {
+	if len(blockID) != 32 {
+		return 0, fmt.Errorf("invalid genesis block id length: %d", len(blockID))
+	}
+	return binary.BigEndian.Uint32(blockID[28:]), nil
+}
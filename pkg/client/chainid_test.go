@@ -0,0 +1,46 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestChainIDFromBlockID(t *testing.T) {
+	genesis, err := common.FromHex("0x00000000000000001ebf88508a03865c71d452e25f4d51194196a1d22b6653dc")
+	require.Nil(t, err)
+	id, err := chainIDFromBlockID(genesis)
+	require.Nil(t, err)
+	assert.Equal(t, uint32(0x2b6653dc), id)
+
+	_, err = chainIDFromBlockID(genesis[:4])
+	assert.Error(t, err)
+}
+
+func TestCheckNetwork(t *testing.T) {
+	g := NewGrpcClient("")
+	assert.Nil(t, g.checkNetwork())
+
+	g.chainID.Store(0xcd8690dc)
+	g.SetExpectedChainID(0xcd8690dc)
+	assert.Nil(t, g.checkNetwork())
+
+	g.SetExpectedChainID(0x2b6653dc)
+	err := g.checkNetwork()
+	assert.True(t, errors.Is(err, ErrWrongNetwork))
+	assert.EqualError(t, err, "connected to the wrong network: node chain ID 0xcd8690dc, expected 0x2b6653dc")
+}
+
+func TestReconnectResetsChainID(t *testing.T) {
+	g := NewGrpcClient("127.0.0.1:1")
+	require.Nil(t, g.Start(grpc.WithInsecure()))
+	defer g.Stop()
+	g.chainID.Store(0x2b6653dc)
+
+	require.Nil(t, g.Reconnect("127.0.0.1:2"))
+	assert.Equal(t, uint32(0), g.chainID.Load())
+}
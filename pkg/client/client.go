@@ -3,8 +3,11 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/fbsobreira/gotron-sdk/pkg/circuitbreaker"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -12,12 +15,45 @@ import (
 
 // GrpcClient controller structure
 type GrpcClient struct {
-	Address     string
-	Conn        *grpc.ClientConn
-	Client      api.WalletClient
-	grpcTimeout time.Duration
-	opts        []grpc.DialOption
-	apiKey      string
+	Address string
+	Conn    *grpc.ClientConn
+	Client  api.WalletClient
+	// SolidityConn and Solidity are set by StartSolidity
+	SolidityConn *grpc.ClientConn
+	Solidity     api.WalletSolidityClient
+	grpcTimeout  time.Duration
+	dialTimeout  time.Duration
+	opts         []grpc.DialOption
+	apiKey       string
+	clientID     string
+
+	// solidityAddress and solidityOpts let Reconnect dial the solidity node again
+	solidityAddress string
+	solidityOpts    []grpc.DialOption
+
+	// compression compressor name set with SetCompression, read on every call
+	compression         atomic.Value
+	compressionRejected atomic.Bool
+	compressionAccepted atomic.Bool
+
+	// abiCache on-chain ABIs fetched by CallContract, by contract address
+	abiCache sync.Map
+	// trc20Tokens contracts checked by GetAccountTokens, guarded by trc20Mu
+	trc20Tokens []string
+	trc20Mu     sync.RWMutex
+	// trc20Valid expiry of positive IsValidTRC20Address answers, by address
+	trc20Valid sync.Map
+	// breaker guards calls to the node once set with SetCircuitBreaker
+	breaker atomic.Pointer[circuitbreaker.Breaker]
+
+	// slowRPCThreshold and slowRPCLogger are read by the interceptor on
+	// every call, while they can be set
+	slowRPCThreshold atomic.Int64
+	slowRPCLogger    atomic.Pointer[SlowRPCLogger]
+
+	// chainID cached by GetChainID, checked against expectedChainID on broadcast
+	chainID         atomic.Uint32
+	expectedChainID uint32
 }
 
 // NewGrpcClient create grpc controller
@@ -43,6 +79,58 @@ func (g *GrpcClient) SetTimeout(timeout time.Duration) {
 	g.grpcTimeout = timeout
 }
 
+// WithTimeout returns a client sharing the connection and settings of g
+// whose calls time out after timeout instead, e.g. for a large block range:
+//
+//	blocks, err := conn.WithTimeout(time.Minute).GetBlockByLimitNext(start, end)
+//
+// g keeps its own timeout. Caches are not shared and Stop must be called on
+// g only, it closes the shared connection.
+func (g *GrpcClient) WithTimeout(timeout time.Duration) *GrpcClient {
+	c := &GrpcClient{
+		Address:         g.Address,
+		Conn:            g.Conn,
+		Client:          g.Client,
+		SolidityConn:    g.SolidityConn,
+		Solidity:        g.Solidity,
+		solidityAddress: g.solidityAddress,
+		solidityOpts:    g.solidityOpts,
+		grpcTimeout:     timeout,
+		dialTimeout:     g.dialTimeout,
+		opts:            g.opts,
+		apiKey:          g.apiKey,
+		clientID:        g.clientID,
+		trc20Tokens:     g.getTRC20Tokens(),
+		expectedChainID: g.expectedChainID,
+	}
+	c.compression.Store(g.compressionName())
+	c.compressionRejected.Store(g.compressionRejected.Load())
+	c.compressionAccepted.Store(g.compressionAccepted.Load())
+	c.chainID.Store(g.chainID.Load())
+	c.breaker.Store(g.breaker.Load())
+	c.slowRPCThreshold.Store(g.slowRPCThreshold.Load())
+	c.slowRPCLogger.Store(g.slowRPCLogger.Load())
+	return c
+}
+
+// SetDialTimeout makes Start and StartSolidity wait up to timeout for the
+// node connection and fail when it is unreachable, instead of returning at
+// once and failing later on the first call. Zero, the default, keeps dialing
+// in the background.
+func (g *GrpcClient) SetDialTimeout(timeout time.Duration) {
+	g.dialTimeout = timeout
+}
+
+// dial connects to address, blocking up to dialTimeout when set
+func (g *GrpcClient) dial(address string, opts []grpc.DialOption) (*grpc.ClientConn, error) {
+	if g.dialTimeout <= 0 {
+		return grpc.Dial(address, opts...)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), g.dialTimeout)
+	defer cancel()
+	return grpc.DialContext(ctx, address, append(opts, grpc.WithBlock())...)
+}
+
 // Start initiate grpc  connection
 func (g *GrpcClient) Start(opts ...grpc.DialOption) error {
 	var err error
@@ -50,7 +138,10 @@ func (g *GrpcClient) Start(opts ...grpc.DialOption) error {
 		g.Address = "grpc.trongrid.io:50051"
 	}
 	g.opts = opts
-	g.Conn, err = grpc.Dial(g.Address, opts...)
+	// the node may be on another network than the previous one
+	g.chainID.Store(0)
+	dialOpts := append([]grpc.DialOption{grpc.WithChainUnaryInterceptor(g.slowRPCInterceptor, g.breakerInterceptor, g.compressionInterceptor)}, opts...)
+	g.Conn, err = g.dial(g.Address, dialOpts)
 
 	if err != nil {
 		return fmt.Errorf("Connecting GRPC Client: %v", err)
@@ -65,11 +156,20 @@ func (g *GrpcClient) SetAPIKey(apiKey string) error {
 	return nil
 }
 
+// SetClientID identifies the caller to node operators, sent as x-client metadata
+// e.g. "my-service/1.2"
+func (g *GrpcClient) SetClientID(clientID string) {
+	g.clientID = clientID
+}
+
 func (g *GrpcClient) getContext() (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithTimeout(context.Background(), g.grpcTimeout)
 	if len(g.apiKey) > 0 {
 		ctx = metadata.AppendToOutgoingContext(ctx, "TRON-PRO-API-KEY", g.apiKey)
 	}
+	if len(g.clientID) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-client", g.clientID)
+	}
 	return ctx, cancel
 }
 
@@ -78,15 +178,27 @@ func (g *GrpcClient) Stop() {
 	if g.Conn != nil {
 		g.Conn.Close()
 	}
+	if g.SolidityConn != nil {
+		g.SolidityConn.Close()
+	}
 }
 
-// Reconnect GRPC
+// Reconnect GRPC, failing when the node can not be reached within the
+// timeout set with SetDialTimeout
 func (g *GrpcClient) Reconnect(url string) error {
 	g.Stop()
 	if len(url) > 0 {
 		g.Address = url
 	}
-	g.Start(g.opts...)
+	if b := g.breaker.Load(); b != nil {
+		b.Reset()
+	}
+	if err := g.Start(g.opts...); err != nil {
+		return err
+	}
+	if len(g.solidityAddress) > 0 {
+		return g.StartSolidity(g.solidityAddress, g.solidityOpts...)
+	}
 	return nil
 }
 
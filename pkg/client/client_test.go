@@ -3,13 +3,16 @@ package client_test
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -57,3 +60,70 @@ func TestSend(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, result)
 }
+
+func TestDialTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	// nothing accepts on a closed listener address
+	unreachable := listener.Addr().String()
+	listener.Close()
+
+	c := client.NewGrpcClient(unreachable)
+	c.SetDialTimeout(200 * time.Millisecond)
+	start := time.Now()
+	err = c.Start(grpc.WithInsecure())
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestWithTimeout(t *testing.T) {
+	// accepts connections but never answers
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	c := client.NewGrpcClientWithTimeout(listener.Addr().String(), time.Minute)
+	require.Nil(t, c.Start(grpc.WithInsecure()))
+	defer c.Stop()
+
+	start := time.Now()
+	_, err = c.WithTimeout(200 * time.Millisecond).GetNowBlock()
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestReconnectSolidity(t *testing.T) {
+	c := client.NewGrpcClient("127.0.0.1:1")
+	require.Nil(t, c.Start(grpc.WithInsecure()))
+	require.Nil(t, c.StartSolidity("127.0.0.1:2", grpc.WithInsecure()))
+	defer c.Stop()
+	before := c.SolidityConn
+
+	require.Nil(t, c.Reconnect(""))
+	require.NotNil(t, c.SolidityConn)
+	require.NotSame(t, before, c.SolidityConn)
+	require.NotEqual(t, connectivity.Shutdown, c.SolidityConn.GetState())
+	require.Equal(t, connectivity.Shutdown, before.GetState())
+}
+
+func TestReconnectDialError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	unreachable := listener.Addr().String()
+	listener.Close()
+
+	c := client.NewGrpcClient(unreachable)
+	require.Nil(t, c.Start(grpc.WithInsecure()))
+	defer c.Stop()
+	c.SetDialTimeout(200 * time.Millisecond)
+	require.Error(t, c.Reconnect(""))
+}
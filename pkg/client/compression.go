@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // register gzip compressor
+	"google.golang.org/grpc/status"
+)
+
+// SetCompression compresses calls with the named compressor, e.g. "gzip",
+// an empty name disables compression. Nodes that reject the compressor
+// are called uncompressed from then on. Only a rejection before the node
+// accepted any compressed call is retried uncompressed: the node refuses
+// the request before running it with codes.Unimplemented or Internal, so
+// even non-idempotent calls like BroadcastTransaction are not run twice.
+func (g *GrpcClient) SetCompression(name string) error {
+	if len(name) > 0 && encoding.GetCompressor(name) == nil {
+		return fmt.Errorf("unknown compressor: %s", name)
+	}
+	g.compression.Store(name)
+	g.compressionRejected.Store(false)
+	g.compressionAccepted.Store(false)
+	return nil
+}
+
+// compressionName compressor set with SetCompression, empty when none
+func (g *GrpcClient) compressionName() string {
+	name, _ := g.compression.Load().(string)
+	return name
+}
+
+func (g *GrpcClient) compressionInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	name := g.compressionName()
+	if len(name) == 0 || g.compressionRejected.Load() {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.UseCompressor(name))...)
+	if err == nil {
+		g.compressionAccepted.Store(true)
+		return nil
+	}
+	if !g.compressionAccepted.Load() && compressionRejected(err) {
+		g.compressionRejected.Store(true)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	return err
+}
+
+func compressionRejected(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || (st.Code() != codes.Unimplemented && st.Code() != codes.Internal) {
+		return false
+	}
+	msg := strings.ToLower(st.Message())
+	return strings.Contains(msg, "compress")
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// compressionNode counts calls and rejects compressed ones while reject is set
+type compressionNode struct {
+	mu                sync.Mutex
+	reject            bool
+	compressed, plain int
+}
+
+func (n *compressionNode) invoke(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, opt := range opts {
+		if _, ok := opt.(grpc.CompressorCallOption); ok {
+			n.compressed++
+			if n.reject {
+				return status.Error(codes.Unimplemented, "grpc: Decompressor is not installed for grpc-encoding \"gzip\"")
+			}
+			return nil
+		}
+	}
+	n.plain++
+	return nil
+}
+
+func TestCompressionInterceptor(t *testing.T) {
+	call := func(g *GrpcClient, node *compressionNode) error {
+		return g.compressionInterceptor(context.Background(), "/protocol.Wallet/BroadcastTransaction", nil, nil, nil, node.invoke)
+	}
+
+	// rejected on the first call, retried once uncompressed
+	g := NewGrpcClient("")
+	require.Nil(t, g.SetCompression("gzip"))
+	node := &compressionNode{reject: true}
+	assert.Nil(t, call(g, node))
+	assert.Nil(t, call(g, node))
+	assert.Equal(t, 1, node.compressed)
+	assert.Equal(t, 2, node.plain)
+
+	// once the node took a compressed call a rejection is not retried
+	require.Nil(t, g.SetCompression("gzip"))
+	node = &compressionNode{}
+	assert.Nil(t, call(g, node))
+	node.reject = true
+	assert.Equal(t, codes.Unimplemented, status.Code(call(g, node)))
+	assert.Equal(t, 2, node.compressed)
+	assert.Equal(t, 0, node.plain)
+
+	// the compressor can change while calls are in flight
+	node = &compressionNode{}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.Nil(t, call(g, node))
+			}
+		}()
+	}
+	require.Nil(t, g.SetCompression(""))
+	require.Nil(t, g.SetCompression("gzip"))
+	wg.Wait()
+	assert.Equal(t, 40, node.compressed+node.plain)
+}
@@ -1,9 +1,13 @@
 package client
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/fbsobreira/gotron-sdk/pkg/abi"
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
@@ -163,6 +167,41 @@ func (g *GrpcClient) TriggerContract(from, contractAddress, method, jsonString s
 	return g.triggerContract(ct, feeLimit)
 }
 
+// DefaultSelfDestructMethod method called by SuicideContract when none is given
+const DefaultSelfDestructMethod = "selfDestruct(address)"
+
+// DefaultSelfDestructFeeLimit fee limit in SUN of SuicideContract
+const DefaultSelfDestructFeeLimit int64 = 100000000
+
+// SuicideContract calls DefaultSelfDestructMethod of contractAddr from
+// ownerAddr, sending the contract balance back to the owner. See
+// SuicideContractTo for another method, beneficiary or fee limit.
+func (g *GrpcClient) SuicideContract(ownerAddr, contractAddr string) (*api.TransactionExtention, error) {
+	return g.SuicideContractTo(ownerAddr, contractAddr, DefaultSelfDestructMethod, ownerAddr,
+		DefaultSelfDestructFeeLimit)
+}
+
+// SuicideContractTo calls the owner only method of a contract that executes
+// SELFDESTRUCT, sending the contract balance to beneficiary. Tron has no
+// system contract to delete a contract, it must expose such a method, and
+// since TIP-652 SELFDESTRUCT only removes code of contracts created in the
+// same transaction, other contracts just get their balance transferred.
+// Access is left to the contract, a caller that is not its owner reverts.
+func (g *GrpcClient) SuicideContractTo(ownerAddr, contractAddr, method, beneficiary string,
+	feeLimit int64) (*api.TransactionExtention, error) {
+	if len(method) == 0 {
+		method = DefaultSelfDestructMethod
+	}
+	if _, err := common.DecodeCheck(beneficiary); err != nil {
+		return nil, fmt.Errorf("invalid beneficiary: %v", err)
+	}
+	param, err := json.Marshal([]map[string]string{{"address": beneficiary}})
+	if err != nil {
+		return nil, err
+	}
+	return g.TriggerContract(ownerAddr, contractAddr, method, string(param), feeLimit, 0, "", 0)
+}
+
 // triggerContract and return tx result
 func (g *GrpcClient) triggerContract(ct *core.TriggerSmartContract, feeLimit int64) (*api.TransactionExtention, error) {
 	ctx, cancel := g.getContext()
@@ -243,6 +282,32 @@ func (g *GrpcClient) estimateEnergy(ct *core.TriggerSmartContract) (*api.Estimat
 	return tx, err
 }
 
+// EstimateCallFee returns the SUN burned for the energy of a contract call:
+// the energy used by a constant execution of ct, less the energy the owner
+// has available, at the current energy price
+func (g *GrpcClient) EstimateCallFee(ct *core.TriggerSmartContract) (int64, error) {
+	tx, err := g.triggerConstantContract(ct)
+	if err != nil {
+		return 0, err
+	}
+	if tx.GetResult().GetCode() > 0 {
+		return 0, fmt.Errorf("%s", string(tx.GetResult().GetMessage()))
+	}
+	resources, err := g.GetAccountResourceDetailed(address.Address(ct.OwnerAddress).String())
+	if err != nil {
+		return 0, err
+	}
+	burned := tx.GetEnergyUsed() - resources.AvailableEnergy()
+	if burned <= 0 {
+		return 0, nil
+	}
+	price, err := g.GetCurrentEnergyPrice()
+	if err != nil {
+		return 0, err
+	}
+	return burned * price, nil
+}
+
 // DeployContract and return tx result
 func (g *GrpcClient) DeployContract(from, contractName string,
 	abi *core.SmartContract_ABI, codeStr string,
@@ -309,6 +374,133 @@ func (g *GrpcClient) UpdateHash(tx *api.TransactionExtention) error {
 	return nil
 }
 
+// GetContract returns the smart contract deployed at address
+func (g *GrpcClient) GetContract(contractAddress string) (*core.SmartContract, error) {
+	contractDesc, err := address.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	return g.Client.GetContract(ctx, GetMessageBytes(contractDesc))
+}
+
+// GetContractInfo returns the contract with its runtime code, the code left
+// deployed once the constructor ran
+func (g *GrpcClient) GetContractInfo(contractAddress string) (*core.SmartContractDataWrapper, error) {
+	contractDesc, err := address.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	return g.Client.GetContractInfo(ctx, GetMessageBytes(contractDesc))
+}
+
+// WaitForContract polls until the contract code is available or timeout expires
+func (g *GrpcClient) WaitForContract(contractAddress string, timeout time.Duration) (*core.SmartContract, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		sm, err := g.GetContract(contractAddress)
+		if err == nil && len(sm.GetBytecode()) > 0 {
+			return sm, nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return nil, fmt.Errorf("contract %s not available after %s: %v", contractAddress, timeout, err)
+			}
+			return nil, fmt.Errorf("contract %s not available after %s", contractAddress, timeout)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// ErrNotAContract is returned when no contract code is deployed at the address
+var ErrNotAContract = fmt.Errorf("address is not a contract")
+
+// GetSmartContractByteCode returns the runtime bytecode deployed at
+// contractAddress, not the creation bytecode of GetContract
+func (g *GrpcClient) GetSmartContractByteCode(contractAddress string) ([]byte, error) {
+	info, err := g.GetContractInfo(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.GetRuntimecode()) == 0 {
+		return nil, ErrNotAContract
+	}
+	return info.GetRuntimecode(), nil
+}
+
+// EmptyCodeHash Keccak-256 of empty code, returned for accounts without code
+const EmptyCodeHash = "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"
+
+// GetAccountCodeHash returns the Keccak-256 of the runtime bytecode deployed
+// at addr as HEX, as EXTCODEHASH does, changing when a contract is deployed
+// again at the same address. Accounts without code get EmptyCodeHash along
+// with ErrNotAContract.
+func (g *GrpcClient) GetAccountCodeHash(addr string) (string, error) {
+	code, err := g.GetSmartContractByteCode(addr)
+	if errors.Is(err, ErrNotAContract) {
+		return EmptyCodeHash, err
+	}
+	if err != nil {
+		return "", err
+	}
+	return common.BytesToHexString(common.Keccak256(code)), nil
+}
+
+// ErrCreationNotFound is returned by GetContractCreator, along with the
+// creator, when the deployment is not in the creator history, e.g. contracts
+// created by other contracts or nodes without the history API
+var ErrCreationNotFound = errors.New("contract creation transaction not found")
+
+// GetContractCreator returns the address that deployed contractAddress and the
+// block of the deployment. The deployment is found in the creator outgoing
+// transactions, served by nodes running the wallet extension (history) API,
+// as the CreateSmartContract transaction whose ID derives the address.
+func (g *GrpcClient) GetContractCreator(contractAddress string) (string, int64, error) {
+	sm, err := g.GetContract(contractAddress)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(sm.GetOriginAddress()) == 0 {
+		return "", 0, ErrNotAContract
+	}
+	contractBytes, err := common.DecodeCheck(contractAddress)
+	if err != nil {
+		return "", 0, err
+	}
+	creator := address.Address(sm.GetOriginAddress())
+
+	for offset := int64(0); ; offset += historyPageSize {
+		page, err := g.accountTransactions(creator.String(), offset, historyPageSize, true)
+		if err != nil {
+			return creator.String(), 0, fmt.Errorf("%w: %v", ErrCreationNotFound, err)
+		}
+		for _, tx := range page.GetTransaction() {
+			contracts := tx.GetTransaction().GetRawData().GetContract()
+			if len(contracts) == 0 || contracts[0].GetType() != core.Transaction_Contract_CreateSmartContract {
+				continue
+			}
+			if !bytes.Equal(address.ComputeContractAddress(creator, tx.GetTxid()), contractBytes) {
+				continue
+			}
+			info, err := g.GetTransactionInfoByID(common.BytesToHexString(tx.GetTxid()))
+			if err != nil {
+				return creator.String(), 0, err
+			}
+			return creator.String(), info.GetBlockNumber(), nil
+		}
+		if len(page.GetTransaction()) < historyPageSize {
+			return creator.String(), 0, ErrCreationNotFound
+		}
+	}
+}
+
 // GetContractABI return smartContract
 func (g *GrpcClient) GetContractABI(contractAddress string) (*core.SmartContract_ABI, error) {
 	var err error
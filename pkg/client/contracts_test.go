@@ -1,6 +1,7 @@
 package client_test
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/fbsobreira/gotron-sdk/pkg/abi"
 	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"github.com/stretchr/testify/assert"
@@ -130,3 +132,38 @@ func TestGetAccountMigrationContract(t *testing.T) {
 	fmt.Println(result["amount"].(*big.Int).Int64())
 	require.Nil(t, err)
 }
+
+func TestEmptyCodeHash(t *testing.T) {
+	assert.Equal(t, client.EmptyCodeHash, common.BytesToHexString(common.Keccak256(nil)))
+}
+
+// codeWallet serves a contract whose creation and runtime code differ
+type codeWallet struct {
+	api.WalletClient
+	runtime []byte
+}
+
+func (w *codeWallet) GetContractInfo(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.SmartContractDataWrapper, error) {
+	return &core.SmartContractDataWrapper{
+		SmartContract: &core.SmartContract{Bytecode: append([]byte{0x60, 0x80}, w.runtime...)},
+		Runtimecode:   w.runtime,
+	}, nil
+}
+
+func TestGetAccountCodeHash(t *testing.T) {
+	wallet := &codeWallet{runtime: []byte{0x60, 0x00, 0xf3}}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+
+	code, err := c.GetSmartContractByteCode("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	require.Nil(t, err)
+	assert.Equal(t, wallet.runtime, code)
+	hash, err := c.GetAccountCodeHash("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	require.Nil(t, err)
+	assert.Equal(t, common.BytesToHexString(common.Keccak256(wallet.runtime)), hash)
+
+	wallet.runtime = nil
+	hash, err = c.GetAccountCodeHash("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	assert.ErrorIs(t, err, client.ErrNotAContract)
+	assert.Equal(t, client.EmptyCodeHash, hash)
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"time"
+)
+
+// SyncThreshold max age of the head block for a node to be considered in sync
+const SyncThreshold = 3 * time.Second
+
+// IsFullySynced checks the node head block against the local clock and
+// returns how far behind it is, a node is synced when the head block is no
+// older than SyncThreshold. The local clock is assumed to be NTP synced.
+func (g *GrpcClient) IsFullySynced() (bool, time.Duration, error) {
+	start := time.Now()
+	block, err := g.GetNowBlock()
+	if err != nil {
+		return false, 0, err
+	}
+	blockTime := time.UnixMilli(block.GetBlockHeader().GetRawData().GetTimestamp())
+	lag := start.Sub(blockTime)
+	if lag < 0 {
+		lag = 0
+	}
+	return lag <= SyncThreshold, lag, nil
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// historyPageSize max transactions requested per history page
+const historyPageSize = 50
+
+// historyConcurrency receipts fetched in parallel by GetAccountHistory
+const historyConcurrency = 8
+
+// historyMaxScan transactions GetAccountHistory reads at most per direction
+// when the node does not serve pages newest first
+const historyMaxScan = 2000
+
+// ErrHistoryTruncated is returned with the receipts when the history holds
+// more transactions than the requested limit
+var ErrHistoryTruncated = errors.New("account history truncated")
+
+// GetTransactionsFromThis returns a page of transactions sent by addr, served
+// by nodes running the wallet extension (history) API
+func (g *GrpcClient) GetTransactionsFromThis(addr string, offset, limit int64) (*api.TransactionListExtention, error) {
+	return g.accountTransactions(addr, offset, limit, true)
+}
+
+// GetTransactionsToThis returns a page of transactions received by addr, served
+// by nodes running the wallet extension (history) API
+func (g *GrpcClient) GetTransactionsToThis(addr string, offset, limit int64) (*api.TransactionListExtention, error) {
+	return g.accountTransactions(addr, offset, limit, false)
+}
+
+func (g *GrpcClient) accountTransactions(addr string, offset, limit int64, outgoing bool) (*api.TransactionListExtention, error) {
+	addrBytes, err := common.DecodeCheck(addr)
+	if err != nil {
+		return nil, err
+	}
+	req := &api.AccountPaginated{
+		Account: &core.Account{Address: addrBytes},
+		Offset:  offset,
+		Limit:   limit,
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	extension := api.NewWalletExtensionClient(g.Conn)
+	if outgoing {
+		return extension.GetTransactionsFromThis2(ctx, req)
+	}
+	return extension.GetTransactionsToThis2(ctx, req)
+}
+
+// GetAccountHistory returns receipts of transactions sent and received by addr,
+// newest block first, at most limit of them. Nodes do not document the order
+// of history pages: while a direction is served newest first its paging stops
+// once limit transactions are read, otherwise up to historyMaxScan of them are
+// read. The latest limit transactions are then picked by their creation time
+// before their receipts are fetched. ErrHistoryTruncated is returned along
+// with the receipts when limit was reached before all pages were read.
+// Receipts that can not be fetched, e.g. of pending transactions, are left
+// out and reported by a TransactionInfoErrors joined to the returned error.
+func (g *GrpcClient) GetAccountHistory(addr string, limit int) ([]*core.TransactionInfo, error) {
+	if limit < 1 {
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	}
+
+	seen := make(map[string]bool)
+	txs := make([]historyTx, 0)
+	truncated := false
+	for _, outgoing := range []bool{true, false} {
+		more, err := g.accountHistory(addr, outgoing, limit, func(tx *api.TransactionExtention) {
+			id := common.BytesToHexString(tx.GetTxid())
+			if !seen[id] {
+				seen[id] = true
+				txs = append(txs, historyTx{id: id, created: createdAt(tx.GetTransaction())})
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		truncated = truncated || more
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].created > txs[j].created
+	})
+	truncated = truncated || len(txs) > limit
+	if truncated {
+		txs = txs[:limit]
+	}
+	ids := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		ids = append(ids, tx.id)
+	}
+
+	infos, err := g.GetTransactionInfos(ids, historyConcurrency)
+	var infoErrs TransactionInfoErrors
+	if err != nil && !errors.As(err, &infoErrs) {
+		return nil, err
+	}
+	history := make([]*core.TransactionInfo, 0, len(infos))
+	for _, info := range infos {
+		history = append(history, info)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].BlockNumber != history[j].BlockNumber {
+			return history[i].BlockNumber > history[j].BlockNumber
+		}
+		return common.BytesToHexString(history[i].Id) < common.BytesToHexString(history[j].Id)
+	})
+
+	switch {
+	case truncated && infoErrs != nil:
+		return history, errors.Join(ErrHistoryTruncated, infoErrs)
+	case truncated:
+		return history, ErrHistoryTruncated
+	case infoErrs != nil:
+		return history, infoErrs
+	}
+	return history, nil
+}
+
+// accountHistory pages the transactions of addr in one direction, passing
+// each to add, and reports whether pages were left unread
+func (g *GrpcClient) accountHistory(addr string, outgoing bool, limit int,
+	add func(tx *api.TransactionExtention)) (bool, error) {
+	read := 0
+	newestFirst := true
+	last := int64(0)
+	for offset := int64(0); ; offset += historyPageSize {
+		page, err := g.accountTransactions(addr, offset, historyPageSize, outgoing)
+		if err != nil {
+			return false, err
+		}
+		for _, tx := range page.GetTransaction() {
+			created := createdAt(tx.GetTransaction())
+			if read > 0 && created > last {
+				newestFirst = false
+			}
+			last = created
+			read++
+			add(tx)
+		}
+		if len(page.GetTransaction()) < historyPageSize {
+			return false, nil
+		}
+		if (newestFirst && read >= limit) || read >= historyMaxScan {
+			return true, nil
+		}
+	}
+}
+
+// historyTx transaction ID listed by a history page
+type historyTx struct {
+	id      string
+	created int64
+}
+
+// createdAt transaction creation time in ms, from its expiration when the
+// optional timestamp is not set
+func createdAt(tx *core.Transaction) int64 {
+	if ts := tx.GetRawData().GetTimestamp(); ts > 0 {
+		return ts
+	}
+	return tx.GetRawData().GetExpiration()
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// historyNode serves history pages oldest first, the order the client must
+// not rely on
+type historyNode struct {
+	api.UnimplementedWalletExtensionServer
+	outgoing, incoming []int64
+	pages              atomic.Int32
+}
+
+func historyTxID(created int64) []byte {
+	id := make([]byte, 32)
+	id[31] = byte(created)
+	return id
+}
+
+func historyPage(created []int64, in *api.AccountPaginated) *api.TransactionListExtention {
+	page := &api.TransactionListExtention{}
+	for i := in.Offset; i < in.Offset+in.Limit && i < int64(len(created)); i++ {
+		page.Transaction = append(page.Transaction, &api.TransactionExtention{
+			Txid:        historyTxID(created[i]),
+			Transaction: &core.Transaction{RawData: &core.TransactionRaw{Timestamp: created[i]}},
+		})
+	}
+	return page
+}
+
+func (n *historyNode) GetTransactionsFromThis2(ctx context.Context, in *api.AccountPaginated) (*api.TransactionListExtention, error) {
+	n.pages.Add(1)
+	return historyPage(n.outgoing, in), nil
+}
+
+func (n *historyNode) GetTransactionsToThis2(ctx context.Context, in *api.AccountPaginated) (*api.TransactionListExtention, error) {
+	n.pages.Add(1)
+	return historyPage(n.incoming, in), nil
+}
+
+// receiptWallet answers receipts with the block number of their creation
+// time, missing ones are pending
+type receiptWallet struct {
+	api.WalletClient
+	pending map[byte]bool
+}
+
+func (w *receiptWallet) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error) {
+	created := in.Value[31]
+	if w.pending[created] {
+		return &core.TransactionInfo{}, nil
+	}
+	return &core.TransactionInfo{Id: in.Value, BlockNumber: int64(created)}, nil
+}
+
+func TestGetAccountHistory(t *testing.T) {
+	node := &historyNode{incoming: []int64{100, 101, 102, 5}}
+	for created := int64(1); created <= 60; created++ {
+		node.outgoing = append(node.outgoing, created)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	server := grpc.NewServer()
+	api.RegisterWalletExtensionServer(server, node)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	c := NewGrpcClient(listener.Addr().String())
+	require.Nil(t, c.Start(grpc.WithInsecure()))
+	defer c.Stop()
+	c.Client = &receiptWallet{pending: map[byte]bool{59: true}}
+
+	history, err := c.GetAccountHistory("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", 5)
+	assert.True(t, errors.Is(err, ErrHistoryTruncated))
+	var infoErrs TransactionInfoErrors
+	require.True(t, errors.As(err, &infoErrs))
+	assert.Contains(t, infoErrs, common.BytesToHexString(historyTxID(59)))
+
+	blocks := make([]int64, 0, len(history))
+	for _, info := range history {
+		blocks = append(blocks, info.BlockNumber)
+	}
+	assert.Equal(t, []int64{102, 101, 100, 60}, blocks)
+
+	history, err = c.GetAccountHistory("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", 100)
+	assert.True(t, errors.As(err, &infoErrs))
+	assert.False(t, errors.Is(err, ErrHistoryTruncated))
+	assert.Len(t, history, 62)
+
+	// served newest first, paging stops once limit transactions are read
+	node.outgoing = node.outgoing[:0]
+	for created := int64(200); created > 0; created-- {
+		node.outgoing = append(node.outgoing, created)
+	}
+	node.pages.Store(0)
+	history, err = c.GetAccountHistory("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", 5)
+	assert.True(t, errors.Is(err, ErrHistoryTruncated))
+	assert.Equal(t, int32(2), node.pages.Load())
+	blocks = blocks[:0]
+	for _, info := range history {
+		blocks = append(blocks, info.BlockNumber)
+	}
+	assert.Equal(t, []int64{200, 199, 198, 197, 196}, blocks)
+}
+
+func TestCreatedAt(t *testing.T) {
+	assert.Equal(t, int64(1000), createdAt(&core.Transaction{RawData: &core.TransactionRaw{Timestamp: 1000, Expiration: 61000}}))
+	assert.Equal(t, int64(61000), createdAt(&core.Transaction{RawData: &core.TransactionRaw{Expiration: 61000}}))
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+)
+
+// blockBatchSize max blocks the node returns per GetBlockByLimitNext call
+const blockBatchSize = 100
+
+// BlockIterator walks a block range in ascending order, fetching blocks in batches
+type BlockIterator struct {
+	client  *GrpcClient
+	next    int64
+	end     int64
+	batch   []*api.BlockExtention
+	current *api.BlockExtention
+	err     error
+}
+
+// NewBlockIterator iterates blocks from start to end, both included
+func (g *GrpcClient) NewBlockIterator(start, end int64) *BlockIterator {
+	return &BlockIterator{
+		client: g,
+		next:   start,
+		end:    end,
+	}
+}
+
+// Next advances to the next block, returns false at the end of the range or on error
+func (it *BlockIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.batch) == 0 {
+		if it.next > it.end {
+			return false
+		}
+		last := it.next + blockBatchSize
+		if last > it.end+1 {
+			last = it.end + 1
+		}
+		blocks, err := it.client.GetBlockByLimitNext(it.next, last)
+		if err != nil {
+			it.err = fmt.Errorf("get blocks %d to %d: %v", it.next, last-1, err)
+			return false
+		}
+		if len(blocks.GetBlock()) == 0 {
+			it.err = fmt.Errorf("get blocks %d to %d: empty result", it.next, last-1)
+			return false
+		}
+		it.batch = blocks.GetBlock()
+		it.next = last
+	}
+	it.current = it.batch[0]
+	it.batch = it.batch[1:]
+	return true
+}
+
+// Block returns the current block
+func (it *BlockIterator) Block() *api.BlockExtention {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// GetBlockNumberAt returns the first block produced at or after t,
+// the current block when t is in the future
+func (g *GrpcClient) GetBlockNumberAt(t time.Time) (int64, error) {
+	head, err := g.GetNowBlock()
+	if err != nil {
+		return 0, err
+	}
+	target := t.UnixMilli()
+	low, high := int64(0), head.GetBlockHeader().GetRawData().GetNumber()
+	for low < high {
+		mid := low + (high-low)/2
+		block, err := g.GetBlockByNum(mid)
+		if err != nil {
+			return 0, err
+		}
+		if block.GetBlockHeader().GetRawData().GetTimestamp() < target {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
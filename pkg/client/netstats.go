@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+)
+
+// NetworkStats network wide activity and resource figures. The node has no
+// global usage counter, limits and weights are the staked totals resources
+// are shared from.
+type NetworkStats struct {
+	BlockNum          int64   `json:"blockNum"`
+	TotalTransactions int64   `json:"totalTransactions"`
+	TPS               float64 `json:"tps"`
+	TotalNetLimit     int64   `json:"totalNetLimit"`
+	TotalNetWeight    int64   `json:"totalNetWeight"`
+	TotalEnergyLimit  int64   `json:"totalEnergyLimit"`
+	TotalEnergyWeight int64   `json:"totalEnergyWeight"`
+}
+
+// GetTotalTransactionCount returns the number of transactions the node has stored
+func (g *GrpcClient) GetTotalTransactionCount() (int64, error) {
+	total, err := g.TotalTransaction()
+	if err != nil {
+		return 0, err
+	}
+	return total.GetNum(), nil
+}
+
+// GetTransactionCountByBlockNum returns the number of transactions in block num
+func (g *GrpcClient) GetTransactionCountByBlockNum(num int64) (int64, error) {
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	count, err := g.Client.GetTransactionCountByBlockNum(ctx, &api.NumberMessage{Num: num})
+	if err != nil {
+		return 0, err
+	}
+	return count.GetNum(), nil
+}
+
+// EstimateTPS returns the transactions per second over the latest blocks
+func (g *GrpcClient) EstimateTPS() (float64, error) {
+	list, err := g.GetBlockByLatestNum(cadenceSampleSize)
+	if err != nil {
+		return 0, err
+	}
+	return blockTPS(list.GetBlock())
+}
+
+// GetNetworkStats returns the transaction count, TPS estimate and network
+// resource totals in one call
+func (g *GrpcClient) GetNetworkStats() (*NetworkStats, error) {
+	block, err := g.GetNowBlock()
+	if err != nil {
+		return nil, err
+	}
+	stats := &NetworkStats{BlockNum: block.GetBlockHeader().GetRawData().GetNumber()}
+
+	if stats.TotalTransactions, err = g.GetTotalTransactionCount(); err != nil {
+		return nil, err
+	}
+	if stats.TPS, err = g.EstimateTPS(); err != nil {
+		return nil, err
+	}
+
+	resources, err := g.GetAccountResource(BlackHoleAddress)
+	if err != nil {
+		return nil, err
+	}
+	stats.TotalNetLimit = resources.GetTotalNetLimit()
+	stats.TotalNetWeight = resources.GetTotalNetWeight()
+	stats.TotalEnergyLimit = resources.GetTotalEnergyLimit()
+	stats.TotalEnergyWeight = resources.GetTotalEnergyWeight()
+	return stats, nil
+}
+
+// blockTPS transactions per second of blocks, in any order. Transactions of
+// the oldest block are left out, they were produced before the measured span.
+func blockTPS(blocks []*api.BlockExtention) (float64, error) {
+	if len(blocks) < 2 {
+		return 0, fmt.Errorf("at least 2 blocks needed, got %d", len(blocks))
+	}
+	sorted := append([]*api.BlockExtention{}, blocks...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].GetBlockHeader().GetRawData().GetNumber() < sorted[j].GetBlockHeader().GetRawData().GetNumber()
+	})
+	span := sorted[len(sorted)-1].GetBlockHeader().GetRawData().GetTimestamp() -
+		sorted[0].GetBlockHeader().GetRawData().GetTimestamp()
+	if span <= 0 {
+		return 0, fmt.Errorf("invalid block time span: %dms", span)
+	}
+	txs := 0
+	for _, b := range sorted[1:] {
+		txs += len(b.GetTransactions())
+	}
+	return float64(txs) * 1000 / float64(span), nil
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTPS(t *testing.T) {
+	block := func(number, timestamp int64, txs int) *api.BlockExtention {
+		return &api.BlockExtention{
+			BlockHeader: &core.BlockHeader{
+				RawData: &core.BlockHeaderRaw{Number: number, Timestamp: timestamp},
+			},
+			Transactions: make([]*api.TransactionExtention, txs),
+		}
+	}
+	blocks := []*api.BlockExtention{
+		block(102, 1700000006000, 30),
+		block(100, 1700000000000, 500),
+		block(101, 1700000003000, 60),
+	}
+	tps, err := blockTPS(blocks)
+	require.Nil(t, err)
+	assert.Equal(t, 15.0, tps)
+
+	_, err = blockTPS(blocks[:1])
+	assert.Error(t, err)
+	_, err = blockTPS([]*api.BlockExtention{block(1, 1000, 1), block(2, 1000, 1)})
+	assert.Error(t, err)
+}
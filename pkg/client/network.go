@@ -2,7 +2,14 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
@@ -11,6 +18,10 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrTransactionInfoNotFound is returned when the node has no receipt for a
+// transaction, it is not in a block yet or never will be
+var ErrTransactionInfoNotFound = errors.New("transaction info not found")
+
 // ListNodes provides list of network nodes
 func (g *GrpcClient) ListNodes() (*api.NodeList, error) {
 	ctx, cancel := g.getContext()
@@ -23,6 +34,38 @@ func (g *GrpcClient) ListNodes() (*api.NodeList, error) {
 	return nodeList, nil
 }
 
+// Peer network node known by the connected node
+type Peer struct {
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+}
+
+// ListPeers returns the nodes known by the connected node sorted by host and
+// port, unlike ListNodes errors are returned
+func (g *GrpcClient) ListPeers() ([]Peer, error) {
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	nodeList, err := g.Client.ListNodes(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]Peer, 0, len(nodeList.GetNodes()))
+	for _, node := range nodeList.GetNodes() {
+		peers = append(peers, Peer{
+			Host: string(node.GetAddress().GetHost()),
+			Port: node.GetAddress().GetPort(),
+		})
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].Host != peers[j].Host {
+			return peers[i].Host < peers[j].Host
+		}
+		return peers[i].Port < peers[j].Port
+	})
+	return peers, nil
+}
+
 // GetNextMaintenanceTime get next epoch timestamp
 func (g *GrpcClient) GetNextMaintenanceTime() (*api.NumberMessage, error) {
 	ctx, cancel := g.getContext()
@@ -32,6 +75,34 @@ func (g *GrpcClient) GetNextMaintenanceTime() (*api.NumberMessage, error) {
 		new(api.EmptyMessage))
 }
 
+// NextMaintenanceTime returns the time of the next maintenance period, when
+// votes are tallied and resources recalculated
+func (g *GrpcClient) NextMaintenanceTime() (time.Time, error) {
+	next, err := g.GetNextMaintenanceTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(next.GetNum()), nil
+}
+
+// LastMaintenanceBlock returns the first block produced after the last
+// maintenance period, derived from the maintenance time interval parameter
+func (g *GrpcClient) LastMaintenanceBlock() (int64, error) {
+	next, err := g.NextMaintenanceTime()
+	if err != nil {
+		return 0, err
+	}
+	params, err := g.ChainParametersSnapshot()
+	if err != nil {
+		return 0, err
+	}
+	interval, ok := params["getMaintenanceTimeInterval"]
+	if !ok || interval <= 0 {
+		return 0, fmt.Errorf("maintenance time interval not available")
+	}
+	return g.GetBlockNumberAt(next.Add(-time.Duration(interval) * time.Millisecond))
+}
+
 // TotalTransaction return total transciton in network
 func (g *GrpcClient) TotalTransaction() (*api.NumberMessage, error) {
 	ctx, cancel := g.getContext()
@@ -64,6 +135,33 @@ func (g *GrpcClient) GetTransactionByID(id string) (*core.Transaction, error) {
 	return nil, fmt.Errorf("transaction info not found")
 }
 
+// ErrNotInPendingPool is returned when the node has no pending transaction with the given ID,
+// either it was already included in a block or dropped from the pool
+var ErrNotInPendingPool = fmt.Errorf("transaction not in pending pool")
+
+// GetTransactionFromPending returns a transaction still waiting in the node pending pool
+func (g *GrpcClient) GetTransactionFromPending(id string) (*core.Transaction, error) {
+	transactionID := new(api.BytesMessage)
+	var err error
+
+	transactionID.Value, err = common.FromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("get transaction by id error: %v", err)
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	tx, err := g.Client.GetTransactionFromPending(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if size := proto.Size(tx); size > 0 {
+		return tx, nil
+	}
+	return nil, ErrNotInPendingPool
+}
+
 // GetTransactionInfoByID returns transaction receipt by ID
 func (g *GrpcClient) GetTransactionInfoByID(id string) (*core.TransactionInfo, error) {
 	transactionID := new(api.BytesMessage)
@@ -84,11 +182,76 @@ func (g *GrpcClient) GetTransactionInfoByID(id string) (*core.TransactionInfo, e
 	if bytes.Equal(txi.Id, transactionID.Value) {
 		return txi, nil
 	}
-	return nil, fmt.Errorf("transaction info not found")
+	return nil, ErrTransactionInfoNotFound
+}
+
+// TransactionInfoErrors failed lookups of GetTransactionInfos by transaction ID
+type TransactionInfoErrors map[string]error
+
+func (e TransactionInfoErrors) Error() string {
+	return formatKeyedErrors("transaction info(s)", e)
+}
+
+// formatKeyedErrors lists errs sorted by key after their count
+func formatKeyedErrors(what string, errs map[string]error) string {
+	keys := make([]string, 0, len(errs))
+	for key := range errs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	msgs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", key, errs[key]))
+	}
+	return fmt.Sprintf("%d %s failed: %s", len(errs), what, strings.Join(msgs, "; "))
+}
+
+// GetTransactionInfos fetches receipts of ids with up to concurrency calls in
+// flight. Receipts found are returned even when some lookups fail, the error is
+// then a TransactionInfoErrors holding the failed IDs.
+func (g *GrpcClient) GetTransactionInfos(ids []string, concurrency int) (map[string]*core.TransactionInfo, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("invalid concurrency: %d", concurrency)
+	}
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		infos = make(map[string]*core.TransactionInfo, len(ids))
+		errs  = make(TransactionInfoErrors)
+		sem   = make(chan struct{}, concurrency)
+	)
+	for _, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			info, err := g.GetTransactionInfoByID(id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[id] = err
+				return
+			}
+			infos[id] = info
+		}(id)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return infos, errs
+	}
+	return infos, nil
 }
 
 // Broadcast broadcast TX
 func (g *GrpcClient) Broadcast(tx *core.Transaction) (*api.Return, error) {
+	if err := g.checkNetwork(); err != nil {
+		return nil, err
+	}
 	ctx, cancel := g.getContext()
 	defer cancel()
 	result, err := g.Client.BroadcastTransaction(ctx, tx)
@@ -104,6 +267,53 @@ func (g *GrpcClient) Broadcast(tx *core.Transaction) (*api.Return, error) {
 	return result, nil
 }
 
+// defaultConfirmationTimeout used by BroadcastTransactionAndWait when ctx has no deadline
+const defaultConfirmationTimeout = 60 * time.Second
+
+// WaitForTransaction polls until the transaction is in a block and returns its receipt
+func (g *GrpcClient) WaitForTransaction(ctx context.Context, id string) (*core.TransactionInfo, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		if txi, err := g.GetTransactionInfoByID(id); err == nil {
+			return txi, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("could not confirm transaction %s: %v", id, ctx.Err())
+		}
+	}
+}
+
+// BroadcastTransactionAndWait broadcasts a signed transaction and waits for its
+// receipt, up to 60 seconds unless ctx has a deadline. A receipt is returned
+// along with the error when the transaction executed but failed.
+func (g *GrpcClient) BroadcastTransactionAndWait(ctx context.Context, tx *core.Transaction) (*core.TransactionInfo, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultConfirmationTimeout)
+		defer cancel()
+	}
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(rawData)
+
+	if _, err = g.Broadcast(tx); err != nil {
+		return nil, err
+	}
+	txi, err := g.WaitForTransaction(ctx, common.BytesToHexString(hash[:]))
+	if err != nil {
+		return nil, err
+	}
+	if txi.Result != core.TransactionInfo_SUCESS {
+		return txi, fmt.Errorf("%s", txi.ResMessage)
+	}
+	return txi, nil
+}
+
 // GetNodeInfo current connection
 func (g *GrpcClient) GetNodeInfo() (*core.NodeInfo, error) {
 	ctx, cancel := g.getContext()
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+)
+
+const bandGetReferenceDataMethod = "getReferenceData(string,string)"
+
+// BandOracleMainnet Band Protocol StdReference contract on mainnet read by
+// GetPriceOracle and `chain price`. FIXME: pin the deployed address, until
+// then it must be set or the oracle given with GetPriceOracleAt.
+var BandOracleMainnet = ""
+
+// ErrNoPriceOracle is returned by GetPriceOracle while BandOracleMainnet is
+// not set
+var ErrNoPriceOracle = errors.New("no Band Protocol oracle address set")
+
+// GetPriceOracle reads USD prices from the mainnet Band Protocol oracle,
+// BandOracleMainnet, prices are returned by symbol in USD x 10^8
+func (g *GrpcClient) GetPriceOracle(symbols ...string) (map[string]int64, error) {
+	if BandOracleMainnet == "" {
+		return nil, ErrNoPriceOracle
+	}
+	return g.GetPriceOracleAt(BandOracleMainnet, symbols...)
+}
+
+// GetPriceOracleAt reads USD prices from the Band Protocol StdReference
+// contract at oracleAddress, e.g. on a testnet, prices are returned by
+// symbol in USD x 10^8
+func (g *GrpcClient) GetPriceOracleAt(oracleAddress string, symbols ...string) (map[string]int64, error) {
+	// band rates are USD x 10^18
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(10), nil)
+
+	prices := make(map[string]int64, len(symbols))
+	for _, symbol := range symbols {
+		data, err := abi.Pack(bandGetReferenceDataMethod, []abi.Param{
+			{"string": symbol},
+			{"string": "USD"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		result, err := g.TRC20Call("", oracleAddress, common.BytesToHexString(data), true, 0)
+		if err != nil {
+			return nil, fmt.Errorf("price of %s: %v", symbol, err)
+		}
+		// ReferenceData(rate, lastUpdatedBase, lastUpdatedQuote)
+		if len(result.GetConstantResult()) == 0 || len(result.GetConstantResult()[0]) < 96 {
+			return nil, fmt.Errorf("price of %s: invalid oracle response", symbol)
+		}
+		rate := new(big.Int).SetBytes(result.GetConstantResult()[0][:32])
+		prices[symbol] = rate.Div(rate, scale).Int64()
+	}
+	return prices, nil
+}
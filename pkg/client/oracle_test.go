@@ -0,0 +1,50 @@
+package client_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// oracleWallet answers getReferenceData with rate, USD x 10^18
+type oracleWallet struct {
+	api.WalletClient
+	rate *big.Int
+}
+
+func (w *oracleWallet) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	result := common.LeftPadBytes(w.rate.Bytes(), 32)
+	result = append(result, make([]byte, 64)...)
+	return &api.TransactionExtention{
+		Result:         &api.Return{Result: true},
+		ConstantResult: [][]byte{result},
+	}, nil
+}
+
+func TestGetPriceOracle(t *testing.T) {
+	// 0.12345678 USD
+	rate, _ := new(big.Int).SetString("123456780000000000", 10)
+	c := client.NewGrpcClient("")
+	c.Client = &oracleWallet{rate: rate}
+
+	prices, err := c.GetPriceOracleAt("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "TRX")
+	require.Nil(t, err)
+	assert.Equal(t, map[string]int64{"TRX": 12345678}, prices)
+
+	defer func(oracle string) { client.BandOracleMainnet = oracle }(client.BandOracleMainnet)
+	client.BandOracleMainnet = ""
+	_, err = c.GetPriceOracle("TRX")
+	assert.ErrorIs(t, err, client.ErrNoPriceOracle)
+	client.BandOracleMainnet = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	prices, err = c.GetPriceOracle("TRX")
+	require.Nil(t, err)
+	assert.Equal(t, int64(12345678), prices["TRX"])
+}
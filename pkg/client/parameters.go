@@ -0,0 +1,88 @@
+package client
+
+import (
+	"sort"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// ChainParameters network parameters by key, e.g. "getEnergyFee"
+type ChainParameters map[string]int64
+
+// ChainParameterChange a parameter that differs between two snapshots,
+// Added and Removed mark keys present in only one of them
+type ChainParameterChange struct {
+	Key     string `json:"key"`
+	Old     int64  `json:"old"`
+	New     int64  `json:"new"`
+	Added   bool   `json:"added,omitempty"`
+	Removed bool   `json:"removed,omitempty"`
+}
+
+// GetChainParameters returns the current network parameters
+func (g *GrpcClient) GetChainParameters() (*core.ChainParameters, error) {
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	return g.Client.GetChainParameters(ctx, new(api.EmptyMessage))
+}
+
+// ChainParametersSnapshot returns the current network parameters by key
+func (g *GrpcClient) ChainParametersSnapshot() (ChainParameters, error) {
+	params, err := g.GetChainParameters()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := make(ChainParameters, len(params.GetChainParameter()))
+	for _, p := range params.GetChainParameter() {
+		snapshot[p.GetKey()] = p.GetValue()
+	}
+	return snapshot, nil
+}
+
+// GetMemoFee returns the SUN burned by transactions carrying a memo, 0 on
+// networks not charging for memos
+func (g *GrpcClient) GetMemoFee() (int64, error) {
+	params, err := g.ChainParametersSnapshot()
+	if err != nil {
+		return 0, err
+	}
+	return params.MemoFee(), nil
+}
+
+// MemoFee getMemoFee, SUN burned by transactions carrying a memo
+func (p ChainParameters) MemoFee() int64 {
+	return p["getMemoFee"]
+}
+
+// MemoCost SUN tx pays for its memo on top of bandwidth and energy, 0 when
+// it has none
+func (p ChainParameters) MemoCost(tx *core.Transaction) int64 {
+	if len(tx.GetRawData().GetData()) == 0 {
+		return 0
+	}
+	return p.MemoFee()
+}
+
+// Diff returns the parameters changed since prev sorted by key
+func (p ChainParameters) Diff(prev ChainParameters) []ChainParameterChange {
+	changes := make([]ChainParameterChange, 0)
+	for key, value := range p {
+		old, ok := prev[key]
+		if !ok {
+			changes = append(changes, ChainParameterChange{Key: key, New: value, Added: true})
+		} else if old != value {
+			changes = append(changes, ChainParameterChange{Key: key, Old: old, New: value})
+		}
+	}
+	for key, old := range prev {
+		if _, ok := p[key]; !ok {
+			changes = append(changes, ChainParameterChange{Key: key, Old: old, Removed: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
@@ -0,0 +1,39 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainParametersDiff(t *testing.T) {
+	prev := client.ChainParameters{
+		"getEnergyFee":      420,
+		"getTransactionFee": 1000,
+		"getMemoFee":        1000000,
+	}
+	next := client.ChainParameters{
+		"getEnergyFee":      210,
+		"getTransactionFee": 1000,
+		"getAllowTvmCancun": 1,
+	}
+
+	changes := next.Diff(prev)
+	assert.Equal(t, []client.ChainParameterChange{
+		{Key: "getAllowTvmCancun", New: 1, Added: true},
+		{Key: "getEnergyFee", Old: 420, New: 210},
+		{Key: "getMemoFee", Old: 1000000, Removed: true},
+	}, changes)
+
+	assert.Empty(t, next.Diff(next))
+}
+
+func TestChainParametersMemoCost(t *testing.T) {
+	params := client.ChainParameters{"getMemoFee": 1000000}
+	assert.Equal(t, int64(1000000), params.MemoFee())
+	assert.Equal(t, int64(1000000), params.MemoCost(&core.Transaction{RawData: &core.TransactionRaw{Data: []byte("ref")}}))
+	assert.Equal(t, int64(0), params.MemoCost(&core.Transaction{RawData: &core.TransactionRaw{}}))
+	assert.Equal(t, int64(0), client.ChainParameters{}.MemoCost(&core.Transaction{RawData: &core.TransactionRaw{Data: []byte("ref")}}))
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"sort"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+const (
+	// proposalTransactionFee proposal parameter ID of getTransactionFee
+	proposalTransactionFee int64 = 3
+	// proposalEnergyFee proposal parameter ID of getEnergyFee
+	proposalEnergyFee int64 = 11
+)
+
+const (
+	// genesisBandwidthPrice SUN per byte before any proposal changed it
+	genesisBandwidthPrice = 10
+	// genesisEnergyPrice SUN per energy before any proposal changed it
+	genesisEnergyPrice = 100
+)
+
+// BandwidthPricePoint bandwidth price in effect from BlockNum on
+type BandwidthPricePoint struct {
+	BlockNum          int64
+	PriceInSunPerByte int64
+}
+
+// EnergyPricePoint energy price in effect from BlockNum on
+type EnergyPricePoint struct {
+	BlockNum            int64
+	PriceInSunPerEnergy int64
+}
+
+// priceChange price set by an approved proposal, in effect from At (ms)
+type priceChange struct {
+	At    int64
+	Price int64
+}
+
+// GetCurrentBandwidthPrice returns the SUN burned per byte of bandwidth
+func (g *GrpcClient) GetCurrentBandwidthPrice() (int64, error) {
+	params, err := g.ChainParametersSnapshot()
+	if err != nil {
+		return 0, err
+	}
+	return params["getTransactionFee"], nil
+}
+
+// GetCurrentEnergyPrice returns the SUN burned per unit of energy
+func (g *GrpcClient) GetCurrentEnergyPrice() (int64, error) {
+	params, err := g.ChainParametersSnapshot()
+	if err != nil {
+		return 0, err
+	}
+	return params["getEnergyFee"], nil
+}
+
+// GetBandwidthPriceHistory returns the bandwidth prices the network went
+// through, oldest first. The gRPC API has no price history call, it is
+// rebuilt from approved proposals changing getTransactionFee, each in effect
+// from the first block at or after the proposal expiration.
+func (g *GrpcClient) GetBandwidthPriceHistory() ([]BandwidthPricePoint, error) {
+	changes, err := g.priceHistory(proposalTransactionFee, genesisBandwidthPrice)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]BandwidthPricePoint, 0, len(changes))
+	for _, c := range changes {
+		history = append(history, BandwidthPricePoint{BlockNum: c.At, PriceInSunPerByte: c.Price})
+	}
+	return history, nil
+}
+
+// GetEnergyPriceHistory returns the energy prices the network went through,
+// oldest first, rebuilt from approved proposals changing getEnergyFee
+func (g *GrpcClient) GetEnergyPriceHistory() ([]EnergyPricePoint, error) {
+	changes, err := g.priceHistory(proposalEnergyFee, genesisEnergyPrice)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]EnergyPricePoint, 0, len(changes))
+	for _, c := range changes {
+		history = append(history, EnergyPricePoint{BlockNum: c.At, PriceInSunPerEnergy: c.Price})
+	}
+	return history, nil
+}
+
+// priceHistory returns the price changes of parameter with At holding the
+// block number the change took effect
+func (g *GrpcClient) priceHistory(parameter, genesis int64) ([]priceChange, error) {
+	proposals, err := g.ProposalsList()
+	if err != nil {
+		return nil, err
+	}
+	changes := approvedPriceChanges(proposals, parameter, genesis)
+	for i := range changes {
+		if changes[i].At == 0 {
+			continue
+		}
+		block, err := g.GetBlockNumberAt(time.UnixMilli(changes[i].At))
+		if err != nil {
+			return nil, err
+		}
+		changes[i].At = block
+	}
+	return changes, nil
+}
+
+// approvedPriceChanges lists the values approved proposals gave parameter,
+// ordered by expiration and starting with genesis at 0. Proposals keeping
+// the previous value are left out.
+func approvedPriceChanges(list *api.ProposalList, parameter, genesis int64) []priceChange {
+	approved := make([]priceChange, 0)
+	for _, p := range list.GetProposals() {
+		if p.GetState() != core.Proposal_APPROVED {
+			continue
+		}
+		if price, ok := p.GetParameters()[parameter]; ok {
+			approved = append(approved, priceChange{At: p.GetExpirationTime(), Price: price})
+		}
+	}
+	sort.SliceStable(approved, func(i, j int) bool {
+		return approved[i].At < approved[j].At
+	})
+
+	changes := []priceChange{{At: 0, Price: genesis}}
+	for _, c := range approved {
+		if c.Price == changes[len(changes)-1].Price {
+			continue
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
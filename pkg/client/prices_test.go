@@ -0,0 +1,34 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovedPriceChanges(t *testing.T) {
+	list := &api.ProposalList{Proposals: []*core.Proposal{
+		{ExpirationTime: 3000, State: core.Proposal_APPROVED, Parameters: map[int64]int64{proposalTransactionFee: 1000}},
+		{ExpirationTime: 1000, State: core.Proposal_APPROVED, Parameters: map[int64]int64{proposalTransactionFee: 40, proposalEnergyFee: 40}},
+		{ExpirationTime: 2000, State: core.Proposal_DISAPPROVED, Parameters: map[int64]int64{proposalTransactionFee: 20}},
+		{ExpirationTime: 2500, State: core.Proposal_APPROVED, Parameters: map[int64]int64{proposalTransactionFee: 40}},
+		{ExpirationTime: 4000, State: core.Proposal_APPROVED, Parameters: map[int64]int64{proposalEnergyFee: 420}},
+	}}
+
+	assert.Equal(t, []priceChange{
+		{At: 0, Price: genesisBandwidthPrice},
+		{At: 1000, Price: 40},
+		{At: 3000, Price: 1000},
+	}, approvedPriceChanges(list, proposalTransactionFee, genesisBandwidthPrice))
+
+	assert.Equal(t, []priceChange{
+		{At: 0, Price: genesisEnergyPrice},
+		{At: 1000, Price: 40},
+		{At: 4000, Price: 420},
+	}, approvedPriceChanges(list, proposalEnergyFee, genesisEnergyPrice))
+
+	assert.Equal(t, []priceChange{{At: 0, Price: genesisBandwidthPrice}},
+		approvedPriceChanges(&api.ProposalList{}, proposalTransactionFee, genesisBandwidthPrice))
+}
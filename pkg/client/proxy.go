@@ -0,0 +1,89 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+const (
+	proxyUpgradeMethod        = "upgradeTo(address)"
+	proxyUpgradeAndCallMethod = "upgradeToAndCall(address,bytes)"
+	proxyImplementationMethod = "implementation()"
+)
+
+// ErrUnsupportedProxy is returned by GetProxyImplementation for proxies
+// without an implementation() getter answering caller, e.g. UUPS and
+// OpenZeppelin v5 proxies
+var ErrUnsupportedProxy = errors.New("unsupported proxy: implementation() not available")
+
+// GetProxyImplementation returns the implementation behind an EIP-1967 proxy.
+// Nodes do not serve storage reads over gRPC, so instead of reading the
+// implementation slot this calls implementation(), which transparent proxies
+// only answer when caller is the proxy admin. Other proxies fail with
+// ErrUnsupportedProxy.
+func (g *GrpcClient) GetProxyImplementation(caller, proxyAddress string) (string, error) {
+	callerDesc, err := address.Base58ToAddress(caller)
+	if err != nil {
+		return "", err
+	}
+	proxyDesc, err := address.Base58ToAddress(proxyAddress)
+	if err != nil {
+		return "", err
+	}
+	tx, err := g.triggerConstantContract(&core.TriggerSmartContract{
+		OwnerAddress:    callerDesc.Bytes(),
+		ContractAddress: proxyDesc.Bytes(),
+		Data:            abi.Signature(proxyImplementationMethod),
+	})
+	if err != nil {
+		return "", err
+	}
+	if tx.Result.Code > 0 && tx.Result.Code != api.Return_CONTRACT_EXE_ERROR {
+		return "", fmt.Errorf("%s", string(tx.Result.Message))
+	}
+	// a reverted call is a proxy without the getter
+	if tx.Result.Code > 0 || !constantCallReturned(tx) || len(tx.GetConstantResult()[0]) != 32 {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedProxy, proxyAddress)
+	}
+	return common.EncodeCheck(append([]byte{0x41}, tx.GetConstantResult()[0][12:]...)), nil
+}
+
+// UpgradeProxy calls upgradeTo(implementation) on an EIP-1967 proxy, or
+// upgradeToAndCall(implementation, initData) when initData is set
+func (g *GrpcClient) UpgradeProxy(from, proxyAddress, implementation string, initData []byte,
+	feeLimit int64) (*api.TransactionExtention, error) {
+	fromDesc, err := address.Base58ToAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	proxyDesc, err := address.Base58ToAddress(proxyAddress)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = common.DecodeCheck(implementation); err != nil {
+		return nil, fmt.Errorf("invalid implementation: %v", err)
+	}
+
+	method := proxyUpgradeMethod
+	param := []abi.Param{{"address": implementation}}
+	if len(initData) > 0 {
+		method = proxyUpgradeAndCallMethod
+		param = append(param, abi.Param{"bytes": hex.EncodeToString(initData)})
+	}
+	data, err := abi.Pack(method, param)
+	if err != nil {
+		return nil, err
+	}
+	return g.triggerContract(&core.TriggerSmartContract{
+		OwnerAddress:    fromDesc.Bytes(),
+		ContractAddress: proxyDesc.Bytes(),
+		Data:            data,
+	}, feeLimit)
+}
@@ -0,0 +1,55 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// proxyWallet answers implementation() with implementation, reverts when nil
+type proxyWallet struct {
+	api.WalletClient
+	implementation []byte
+}
+
+func (w *proxyWallet) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	if w.implementation == nil {
+		return &api.TransactionExtention{
+			Result: &api.Return{Code: api.Return_CONTRACT_EXE_ERROR, Message: []byte("REVERT opcode executed")},
+			Transaction: &core.Transaction{
+				Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}},
+			},
+		}, nil
+	}
+	return &api.TransactionExtention{
+		Result:         &api.Return{Result: true},
+		ConstantResult: [][]byte{common.LeftPadBytes(w.implementation, 32)},
+	}, nil
+}
+
+func TestGetProxyImplementation(t *testing.T) {
+	const (
+		admin = "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9"
+		proxy = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	)
+	implementation, err := common.DecodeCheck("TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8")
+	require.Nil(t, err)
+	wallet := &proxyWallet{implementation: implementation[1:]}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+
+	current, err := c.GetProxyImplementation(admin, proxy)
+	require.Nil(t, err)
+	assert.Equal(t, "TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8", current)
+
+	wallet.implementation = nil
+	_, err = c.GetProxyImplementation(admin, proxy)
+	assert.ErrorIs(t, err, client.ErrUnsupportedProxy)
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// ErrReceiptsMismatch is returned by GetBlockWithReceipts when the receipts
+// returned by the node do not match the block transactions
+var ErrReceiptsMismatch = errors.New("block receipts mismatch")
+
+// BlockWithReceipts a block and the receipts of its transactions, Receipts[i]
+// belongs to Block.Transactions[i]
+type BlockWithReceipts struct {
+	Block    *core.Block
+	Receipts []*core.TransactionInfo
+}
+
+// GetBlockWithReceipts fetches a block and its transaction receipts in
+// parallel, matched by transaction ID
+func (g *GrpcClient) GetBlockWithReceipts(blockNum int64) (*BlockWithReceipts, error) {
+	var (
+		wg       sync.WaitGroup
+		block    *api.BlockExtention
+		infos    *api.TransactionInfoList
+		blockErr error
+		infosErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		block, blockErr = g.GetBlockByNum(blockNum)
+	}()
+	go func() {
+		defer wg.Done()
+		infos, infosErr = g.GetBlockInfoByNum(blockNum)
+	}()
+	wg.Wait()
+	if blockErr != nil {
+		return nil, blockErr
+	}
+	if infosErr != nil {
+		return nil, infosErr
+	}
+	return mergeReceipts(block, infos.GetTransactionInfo())
+}
+
+func mergeReceipts(block *api.BlockExtention, infos []*core.TransactionInfo) (*BlockWithReceipts, error) {
+	txs := block.GetTransactions()
+	if len(txs) != len(infos) {
+		return nil, fmt.Errorf("%w: block %d has %d transactions and %d receipts", ErrReceiptsMismatch,
+			block.GetBlockHeader().GetRawData().GetNumber(), len(txs), len(infos))
+	}
+	byID := make(map[string]*core.TransactionInfo, len(infos))
+	for _, info := range infos {
+		byID[common.BytesToHexString(info.GetId())] = info
+	}
+
+	result := &BlockWithReceipts{
+		Block: &core.Block{
+			BlockHeader:  block.GetBlockHeader(),
+			Transactions: make([]*core.Transaction, 0, len(txs)),
+		},
+		Receipts: make([]*core.TransactionInfo, 0, len(txs)),
+	}
+	for _, tx := range txs {
+		id := common.BytesToHexString(tx.GetTxid())
+		info, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: no receipt for transaction %s", ErrReceiptsMismatch, id)
+		}
+		result.Block.Transactions = append(result.Block.Transactions, tx.GetTransaction())
+		result.Receipts = append(result.Receipts, info)
+	}
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeReceipts(t *testing.T) {
+	block := &api.BlockExtention{Transactions: []*api.TransactionExtention{
+		{Txid: []byte{0x01}, Transaction: &core.Transaction{}},
+		{Txid: []byte{0x02}, Transaction: &core.Transaction{}},
+	}}
+	infos := []*core.TransactionInfo{
+		{Id: []byte{0x02}, BlockNumber: 10},
+		{Id: []byte{0x01}, BlockNumber: 10},
+	}
+
+	merged, err := mergeReceipts(block, infos)
+	require.Nil(t, err)
+	require.Len(t, merged.Block.Transactions, 2)
+	assert.Equal(t, []byte{0x01}, merged.Receipts[0].Id)
+	assert.Equal(t, []byte{0x02}, merged.Receipts[1].Id)
+
+	_, err = mergeReceipts(block, infos[:1])
+	assert.True(t, errors.Is(err, ErrReceiptsMismatch))
+
+	infos[0].Id = []byte{0x03}
+	_, err = mergeReceipts(block, infos)
+	assert.True(t, errors.Is(err, ErrReceiptsMismatch))
+}
@@ -1,6 +1,10 @@
 package client
 
 import (
+	"fmt"
+	"sync"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/account"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
@@ -22,6 +26,81 @@ func (g *GrpcClient) GetAccountResource(addr string) (*api.AccountResourceMessag
 	return g.Client.GetAccountResource(ctx, account)
 }
 
+// GetAccountResourceDetailed from BASE58 address including network totals
+func (g *GrpcClient) GetAccountResourceDetailed(addr string) (*account.Resources, error) {
+	res, err := g.GetAccountResource(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &account.Resources{
+		FreeNetUsed:          res.GetFreeNetUsed(),
+		FreeNetLimit:         res.GetFreeNetLimit(),
+		NetUsed:              res.GetNetUsed(),
+		NetLimit:             res.GetNetLimit(),
+		AssetNetUsed:         res.GetAssetNetUsed(),
+		AssetNetLimit:        res.GetAssetNetLimit(),
+		EnergyUsed:           res.GetEnergyUsed(),
+		EnergyLimit:          res.GetEnergyLimit(),
+		TronPowerUsed:        res.GetTronPowerUsed(),
+		TronPowerLimit:       res.GetTronPowerLimit(),
+		StorageUsed:          res.GetStorageUsed(),
+		StorageLimit:         res.GetStorageLimit(),
+		TotalNetLimit:        res.GetTotalNetLimit(),
+		TotalNetWeight:       res.GetTotalNetWeight(),
+		TotalEnergyLimit:     res.GetTotalEnergyLimit(),
+		TotalEnergyWeight:    res.GetTotalEnergyWeight(),
+		TotalTronPowerWeight: res.GetTotalTronPowerWeight(),
+	}, nil
+}
+
+// AccountResourceErrors failed lookups of GetAccountResources by address
+type AccountResourceErrors map[string]error
+
+func (e AccountResourceErrors) Error() string {
+	return formatKeyedErrors("account resource(s)", e)
+}
+
+// GetAccountResources fetches the resources of addrs with up to concurrency
+// calls in flight. Resources found are returned even when some lookups fail,
+// the error is then an AccountResourceErrors holding the failed addresses.
+func (g *GrpcClient) GetAccountResources(addrs []string, concurrency int) (map[string]*account.Resources, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("invalid concurrency: %d", concurrency)
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		resources = make(map[string]*account.Resources, len(addrs))
+		errs      = make(AccountResourceErrors)
+		sem       = make(chan struct{}, concurrency)
+	)
+	for _, addr := range addrs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(addr string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res, err := g.GetAccountResourceDetailed(addr)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[addr] = err
+				return
+			}
+			resources[addr] = res
+		}(addr)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return resources, errs
+	}
+	return resources, nil
+}
+
 // GetDelegatedResources from BASE58 address
 func (g *GrpcClient) GetDelegatedResources(address string) ([]*api.DelegatedResourceList, error) {
 	addrBytes, err := common.DecodeCheck(address)
@@ -81,6 +160,25 @@ func (g *GrpcClient) GetDelegatedResourcesV2(address string) ([]*api.DelegatedRe
 	return result, nil
 }
 
+// GetDelegatedResourceV2 returns stake 2.0 resources delegated by from to to
+func (g *GrpcClient) GetDelegatedResourceV2(from, to string) (*api.DelegatedResourceList, error) {
+	fromBytes, err := common.DecodeCheck(from)
+	if err != nil {
+		return nil, err
+	}
+	toBytes, err := common.DecodeCheck(to)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	return g.Client.GetDelegatedResourceV2(ctx, &api.DelegatedResourceMessage{
+		FromAddress: fromBytes,
+		ToAddress:   toBytes,
+	})
+}
+
 // GetCanDelegatedMaxSize from BASE58 address
 func (g *GrpcClient) GetCanDelegatedMaxSize(address string, resource int32) (*api.CanDelegatedMaxSizeResponseMessage, error) {
 	addrBytes, err := common.DecodeCheck(address)
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountResourcesInvalid(t *testing.T) {
+	g := NewGrpcClient("")
+	_, err := g.GetAccountResources([]string{"a"}, 0)
+	assert.Error(t, err)
+
+	// invalid addresses fail before reaching the node
+	resources, err := g.GetAccountResources([]string{"T123", "bad"}, 4)
+	assert.Empty(t, resources)
+	errs, ok := err.(AccountResourceErrors)
+	require.True(t, ok)
+	assert.Len(t, errs, 2)
+	assert.Contains(t, err.Error(), "2 account resource(s) failed: T123: ")
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// RewardWithdrawal a successful WithdrawBalanceContract, amount in SUN
+type RewardWithdrawal struct {
+	TxID        string
+	BlockNumber int64
+	Timestamp   time.Time
+	Amount      int64
+}
+
+// GetRewardHistory returns reward withdrawals made by addr between from and to.
+// The node has no per account history so every block in the range is fetched,
+// one day is close to 29000 blocks. Receipts are only fetched for blocks with
+// a withdrawal of addr, all of a block at once.
+func (g *GrpcClient) GetRewardHistory(addr string, from, to time.Time) ([]RewardWithdrawal, error) {
+	owner, err := common.DecodeCheck(addr)
+	if err != nil {
+		return nil, err
+	}
+	start, err := g.GetBlockNumberAt(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := g.GetBlockNumberAt(to)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals := make([]RewardWithdrawal, 0)
+	it := g.NewBlockIterator(start, end)
+	for it.Next() {
+		block := it.Block()
+		header := block.GetBlockHeader().GetRawData()
+		if header.GetTimestamp() > to.UnixMilli() {
+			break
+		}
+		txIDs := make([]string, 0)
+		for _, tx := range block.GetTransactions() {
+			contracts := tx.GetTransaction().GetRawData().GetContract()
+			if len(contracts) == 0 || contracts[0].Type != core.Transaction_Contract_WithdrawBalanceContract {
+				continue
+			}
+			contract := &core.WithdrawBalanceContract{}
+			if err = contracts[0].GetParameter().UnmarshalTo(contract); err != nil {
+				return nil, err
+			}
+			if bytes.Equal(contract.OwnerAddress, owner) {
+				txIDs = append(txIDs, common.BytesToHexString(tx.GetTxid()))
+			}
+		}
+		if len(txIDs) == 0 {
+			continue
+		}
+
+		infos, err := g.GetBlockInfoByNum(header.GetNumber())
+		if err != nil {
+			return nil, err
+		}
+		receipts := make(map[string]*core.TransactionInfo, len(infos.GetTransactionInfo()))
+		for _, info := range infos.GetTransactionInfo() {
+			receipts[common.BytesToHexString(info.GetId())] = info
+		}
+		for _, txID := range txIDs {
+			info, ok := receipts[txID]
+			if !ok {
+				return nil, fmt.Errorf("block %d: no receipt for %s", header.GetNumber(), txID)
+			}
+			if info.Result != core.TransactionInfo_SUCESS {
+				continue
+			}
+			withdrawals = append(withdrawals, RewardWithdrawal{
+				TxID:        txID,
+				BlockNumber: header.GetNumber(),
+				Timestamp:   time.UnixMilli(header.GetTimestamp()),
+				Amount:      info.WithdrawAmount,
+			})
+		}
+	}
+	if err = it.Err(); err != nil {
+		return nil, err
+	}
+	return withdrawals, nil
+}
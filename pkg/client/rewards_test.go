@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// rewardWallet node serving blocks 0 to 9, three seconds apart, and their
+// receipts, others panic
+type rewardWallet struct {
+	api.WalletClient
+	blocks    []*api.BlockExtention
+	receipts  map[int64][]*core.TransactionInfo
+	infoCalls map[int64]int
+}
+
+func rewardBlockTime(num int64) int64 {
+	return 1700000000000 + num*3000
+}
+
+func (w *rewardWallet) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return w.blocks[len(w.blocks)-1], nil
+}
+
+func (w *rewardWallet) GetBlockByNum2(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return w.blocks[in.Num], nil
+}
+
+func (w *rewardWallet) GetBlockByLimitNext2(ctx context.Context, in *api.BlockLimit, opts ...grpc.CallOption) (*api.BlockListExtention, error) {
+	return &api.BlockListExtention{Block: w.blocks[in.StartNum:in.EndNum]}, nil
+}
+
+func (w *rewardWallet) GetTransactionInfoByBlockNum(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.TransactionInfoList, error) {
+	w.infoCalls[in.Num]++
+	return &api.TransactionInfoList{TransactionInfo: w.receipts[in.Num]}, nil
+}
+
+// addWithdrawal puts a withdrawal of owner in block num with its receipt
+func (w *rewardWallet) addWithdrawal(t *testing.T, num int64, owner string, id byte, result core.TransactionInfoCode, amount int64) {
+	addr, err := common.DecodeCheck(owner)
+	require.Nil(t, err)
+	param, err := anypb.New(&core.WithdrawBalanceContract{OwnerAddress: addr})
+	require.Nil(t, err)
+	txID := make([]byte, 32)
+	txID[31] = id
+	w.blocks[num].Transactions = append(w.blocks[num].Transactions, &api.TransactionExtention{
+		Txid: txID,
+		Transaction: &core.Transaction{RawData: &core.TransactionRaw{
+			Contract: []*core.Transaction_Contract{{Type: core.Transaction_Contract_WithdrawBalanceContract, Parameter: param}},
+		}},
+	})
+	w.receipts[num] = append(w.receipts[num], &core.TransactionInfo{Id: txID, Result: result, WithdrawAmount: amount})
+}
+
+func TestGetRewardHistory(t *testing.T) {
+	const (
+		owner = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
+		other = "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9"
+	)
+	wallet := &rewardWallet{receipts: make(map[int64][]*core.TransactionInfo), infoCalls: make(map[int64]int)}
+	for num := int64(0); num < 10; num++ {
+		wallet.blocks = append(wallet.blocks, &api.BlockExtention{BlockHeader: &core.BlockHeader{
+			RawData: &core.BlockHeaderRaw{Number: num, Timestamp: rewardBlockTime(num)},
+		}})
+	}
+	wallet.addWithdrawal(t, 1, owner, 1, core.TransactionInfo_SUCESS, 50)
+	wallet.addWithdrawal(t, 3, owner, 2, core.TransactionInfo_SUCESS, 100)
+	wallet.addWithdrawal(t, 3, owner, 3, core.TransactionInfo_FAILED, 0)
+	wallet.addWithdrawal(t, 3, other, 4, core.TransactionInfo_SUCESS, 300)
+	wallet.addWithdrawal(t, 4, other, 5, core.TransactionInfo_SUCESS, 400)
+	wallet.addWithdrawal(t, 6, owner, 6, core.TransactionInfo_SUCESS, 200)
+	wallet.addWithdrawal(t, 8, owner, 7, core.TransactionInfo_SUCESS, 500)
+	c := NewGrpcClient("")
+	c.Client = wallet
+
+	withdrawals, err := c.GetRewardHistory(owner, time.UnixMilli(rewardBlockTime(2)), time.UnixMilli(rewardBlockTime(6)))
+	require.Nil(t, err)
+	require.Len(t, withdrawals, 2)
+	assert.Equal(t, int64(3), withdrawals[0].BlockNumber)
+	assert.Equal(t, int64(100), withdrawals[0].Amount)
+	assert.Equal(t, time.UnixMilli(rewardBlockTime(3)), withdrawals[0].Timestamp)
+	assert.Equal(t, int64(6), withdrawals[1].BlockNumber)
+	assert.Equal(t, int64(200), withdrawals[1].Amount)
+
+	// one receipt call per block holding a withdrawal of owner
+	assert.Equal(t, map[int64]int{3: 1, 6: 1}, wallet.infoCalls)
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// SlowRPCLogger receives calls that took longer than the slow RPC threshold
+type SlowRPCLogger func(method string, duration time.Duration, err error)
+
+// SetSlowRPCThreshold reports calls slower than d to the slow RPC logger, a
+// warning on the global zap logger unless set with SetSlowRPCLogger. Zero,
+// the default, disables it.
+func (g *GrpcClient) SetSlowRPCThreshold(d time.Duration) {
+	g.slowRPCThreshold.Store(int64(d))
+}
+
+// SetSlowRPCLogger replaces the function receiving slow calls, nil restores
+// the zap warning
+func (g *GrpcClient) SetSlowRPCLogger(logger SlowRPCLogger) {
+	if logger == nil {
+		g.slowRPCLogger.Store(nil)
+		return
+	}
+	g.slowRPCLogger.Store(&logger)
+}
+
+func logSlowRPC(method string, duration time.Duration, err error) {
+	zap.L().Warn("Slow RPC", zap.String("method", method), zap.Duration("duration", duration), zap.Error(err))
+}
+
+func (g *GrpcClient) slowRPCInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	threshold := time.Duration(g.slowRPCThreshold.Load())
+	if threshold <= 0 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if duration := time.Since(start); duration > threshold {
+		logger := logSlowRPC
+		if custom := g.slowRPCLogger.Load(); custom != nil {
+			logger = *custom
+		}
+		logger(method, duration, err)
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestSlowRPCInterceptor(t *testing.T) {
+	g := NewGrpcClient("")
+	var (
+		mu   sync.Mutex
+		slow []string
+	)
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	}
+
+	// settings change while calls are in flight
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.Nil(t, g.slowRPCInterceptor(context.Background(), "/protocol.Wallet/GetNowBlock2", nil, nil, nil, invoker))
+			}
+		}()
+	}
+	g.SetSlowRPCLogger(func(method string, duration time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		slow = append(slow, method)
+	})
+	g.SetSlowRPCThreshold(time.Millisecond)
+	wg.Wait()
+
+	mu.Lock()
+	slow = nil
+	mu.Unlock()
+	assert.Nil(t, g.slowRPCInterceptor(context.Background(), "/protocol.Wallet/GetNowBlock2", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"/protocol.Wallet/GetNowBlock2"}, slow)
+
+	g.SetSlowRPCThreshold(0)
+	assert.Nil(t, g.slowRPCInterceptor(context.Background(), "/protocol.Wallet/GetNowBlock2", nil, nil, nil, invoker))
+	assert.Len(t, slow, 1)
+}
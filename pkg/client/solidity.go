@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc"
+)
+
+// ErrNoSolidityNode is returned by solidity calls before StartSolidity
+var ErrNoSolidityNode = errors.New("no solidity node connection")
+
+// solidityPollInterval wait between WaitForSolidity lookups, about one block
+const solidityPollInterval = 3 * time.Second
+
+// StartSolidity connects to the WalletSolidity API, which only serves
+// solidified (irreversible) data, e.g. grpc.trongrid.io:50052. Stop closes
+// both connections, Reconnect opens both again.
+func (g *GrpcClient) StartSolidity(address string, opts ...grpc.DialOption) error {
+	dialOpts := append([]grpc.DialOption{grpc.WithChainUnaryInterceptor(g.slowRPCInterceptor)}, opts...)
+	conn, err := g.dial(address, dialOpts)
+	if err != nil {
+		return fmt.Errorf("Connecting GRPC Solidity Client: %v", err)
+	}
+	g.SolidityConn = conn
+	g.Solidity = api.NewWalletSolidityClient(conn)
+	g.solidityAddress = address
+	g.solidityOpts = opts
+	return nil
+}
+
+// GetSolidTransactionInfoByID returns the receipt of a solidified transaction
+func (g *GrpcClient) GetSolidTransactionInfoByID(id string) (*core.TransactionInfo, error) {
+	if g.Solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	transactionID, err := common.FromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("get transaction by id error: %v", err)
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	txi, err := g.Solidity.GetTransactionInfoById(ctx, GetMessageBytes(transactionID))
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(txi.Id, transactionID) {
+		return txi, nil
+	}
+	return nil, ErrTransactionInfoNotFound
+}
+
+// WaitForSolidity polls the solidity node until the transaction is solidified,
+// i.e. its block was confirmed by 2/3 of the witnesses and can not be reverted.
+// This takes about a minute after inclusion.
+func (g *GrpcClient) WaitForSolidity(id string, timeout time.Duration) (*core.TransactionInfo, error) {
+	if g.Solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		txi, err := g.GetSolidTransactionInfoByID(id)
+		if err == nil {
+			return txi, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("transaction %s not solidified after %s", id, timeout)
+		}
+		time.Sleep(solidityPollInterval)
+	}
+}
+
+// GetNowBlockSolidity returns the latest solidified block
+func (g *GrpcClient) GetNowBlockSolidity() (*api.BlockExtention, error) {
+	if g.Solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	result, err := g.Solidity.GetNowBlock2(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return nil, fmt.Errorf("Get solid block now: %v", err)
+	}
+	return result, nil
+}
+
+// GetBlockByNumSolidity returns a solidified block by number, the node fails
+// for blocks above GetNowBlockSolidity
+func (g *GrpcClient) GetBlockByNumSolidity(num int64) (*api.BlockExtention, error) {
+	if g.Solidity == nil {
+		return nil, ErrNoSolidityNode
+	}
+	numMessage := new(api.NumberMessage)
+	numMessage.Num = num
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+
+	maxSizeOption := grpc.MaxCallRecvMsgSize(32 * 10e6)
+	result, err := g.Solidity.GetBlockByNum2(ctx, numMessage, maxSizeOption)
+	if err != nil {
+		return nil, fmt.Errorf("Get solid block by num: %v", err)
+	}
+	return result, nil
+}
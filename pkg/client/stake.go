@@ -0,0 +1,140 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// minFreezeAmount smallest FreezeBalanceV2 amount accepted, 1 TRX in SUN
+const minFreezeAmount = 1000000
+
+// freezeReserve SUN GetMaxFreezeAmount leaves unstaked for later fees
+const freezeReserve = 1000000
+
+// ErrStakeNotAllowed is returned by CanFreeze and CanUnfreeze, wrapped with
+// the reason the operation would be rejected
+var ErrStakeNotAllowed = errors.New("stake operation not allowed")
+
+// CanFreeze checks that addr can stake amount SUN for resource with
+// FreezeBalanceV2, returning why not otherwise
+func (g *GrpcClient) CanFreeze(addr string, amount int64, resource core.ResourceCode) error {
+	params, err := g.ChainParametersSnapshot()
+	if err != nil {
+		return err
+	}
+	acc, err := g.GetAccount(addr)
+	if err != nil {
+		return err
+	}
+	return checkFreeze(acc, params, amount, resource)
+}
+
+// GetMaxFreezeAmount returns the most SUN addr can stake for resource: the
+// balance less 1 TRX kept for later operations. For ENERGY the TRX burned
+// for the bandwidth of the freeze itself, when not covered by staked or free
+// bandwidth, is subtracted too. Zero means less than 1 TRX can be staked.
+func (g *GrpcClient) GetMaxFreezeAmount(addr string, resource core.ResourceCode) (int64, error) {
+	acc, err := g.GetAccount(addr)
+	if err != nil {
+		return 0, err
+	}
+	if maxFreezeAmount(acc.GetBalance(), 0) == 0 {
+		return 0, nil
+	}
+	var fee int64
+	if resource == core.ResourceCode_ENERGY {
+		// a minimal freeze has the size of the real one
+		tx, err := g.FreezeBalanceV2(addr, resource, minFreezeAmount)
+		if err != nil {
+			return 0, err
+		}
+		cost, err := g.EstimateBandwidthCost(addr, tx.GetTransaction())
+		if err != nil {
+			return 0, err
+		}
+		fee = cost.Fee
+	}
+	return maxFreezeAmount(acc.GetBalance(), fee), nil
+}
+
+// maxFreezeAmount balance less freezeReserve and fee, zero when below the
+// minimum freeze
+func maxFreezeAmount(balance, fee int64) int64 {
+	amount := balance - freezeReserve - fee
+	if amount < minFreezeAmount {
+		return 0
+	}
+	return amount
+}
+
+// CanUnfreeze checks that addr can unstake amount SUN of resource with
+// UnfreezeBalanceV2, returning why not otherwise
+func (g *GrpcClient) CanUnfreeze(addr string, amount int64, resource core.ResourceCode) error {
+	params, err := g.ChainParametersSnapshot()
+	if err != nil {
+		return err
+	}
+	acc, err := g.GetAccount(addr)
+	if err != nil {
+		return err
+	}
+	count, err := g.GetAvailableUnfreezeCount(addr)
+	if err != nil {
+		return err
+	}
+	return checkUnfreeze(acc, params, count.GetCount(), amount, resource)
+}
+
+func checkStakeResource(params ChainParameters, resource core.ResourceCode) error {
+	if params["getUnfreezeDelayDays"] == 0 {
+		return fmt.Errorf("%w: stake 2.0 is not enabled on this network", ErrStakeNotAllowed)
+	}
+	switch resource {
+	case core.ResourceCode_BANDWIDTH, core.ResourceCode_ENERGY:
+	case core.ResourceCode_TRON_POWER:
+		if params["getAllowNewResourceModel"] == 0 {
+			return fmt.Errorf("%w: TRON_POWER staking is not enabled on this network", ErrStakeNotAllowed)
+		}
+	default:
+		return fmt.Errorf("%w: invalid resource %s", ErrStakeNotAllowed, resource.String())
+	}
+	return nil
+}
+
+func checkFreeze(acc *core.Account, params ChainParameters, amount int64, resource core.ResourceCode) error {
+	if err := checkStakeResource(params, resource); err != nil {
+		return err
+	}
+	if amount < minFreezeAmount {
+		return fmt.Errorf("%w: amount %d SUN is below the 1 TRX minimum", ErrStakeNotAllowed, amount)
+	}
+	if acc.GetBalance() < amount {
+		return fmt.Errorf("%w: balance %d SUN is below amount %d", ErrStakeNotAllowed, acc.GetBalance(), amount)
+	}
+	return nil
+}
+
+func checkUnfreeze(acc *core.Account, params ChainParameters, unfreezeLeft, amount int64, resource core.ResourceCode) error {
+	if err := checkStakeResource(params, resource); err != nil {
+		return err
+	}
+	if amount <= 0 {
+		return fmt.Errorf("%w: invalid amount %d", ErrStakeNotAllowed, amount)
+	}
+	if unfreezeLeft <= 0 {
+		return fmt.Errorf("%w: too many pending unfreezes, withdraw expired ones first", ErrStakeNotAllowed)
+	}
+	var frozen int64
+	for _, f := range acc.GetFrozenV2() {
+		if f.GetType() == resource {
+			frozen += f.GetAmount()
+		}
+	}
+	if frozen < amount {
+		return fmt.Errorf("%w: %d SUN staked for %s, below amount %d",
+			ErrStakeNotAllowed, frozen, resource.String(), amount)
+	}
+	return nil
+}
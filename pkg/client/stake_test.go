@@ -0,0 +1,43 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStake(t *testing.T) {
+	params := ChainParameters{"getUnfreezeDelayDays": 14}
+	acc := &core.Account{
+		Balance: 5000000,
+		FrozenV2: []*core.Account_FreezeV2{
+			{Type: core.ResourceCode_BANDWIDTH, Amount: 2000000},
+			{Type: core.ResourceCode_ENERGY, Amount: 3000000},
+		},
+	}
+
+	assert.Nil(t, checkFreeze(acc, params, 5000000, core.ResourceCode_ENERGY))
+	assert.EqualError(t, checkFreeze(acc, params, 999999, core.ResourceCode_ENERGY),
+		"stake operation not allowed: amount 999999 SUN is below the 1 TRX minimum")
+	assert.EqualError(t, checkFreeze(acc, params, 6000000, core.ResourceCode_ENERGY),
+		"stake operation not allowed: balance 5000000 SUN is below amount 6000000")
+	assert.True(t, errors.Is(checkFreeze(acc, params, 1000000, core.ResourceCode_TRON_POWER), ErrStakeNotAllowed))
+	assert.EqualError(t, checkFreeze(acc, ChainParameters{}, 1000000, core.ResourceCode_ENERGY),
+		"stake operation not allowed: stake 2.0 is not enabled on this network")
+
+	assert.Nil(t, checkUnfreeze(acc, params, 32, 3000000, core.ResourceCode_ENERGY))
+	assert.EqualError(t, checkUnfreeze(acc, params, 32, 3000000, core.ResourceCode_BANDWIDTH),
+		"stake operation not allowed: 2000000 SUN staked for BANDWIDTH, below amount 3000000")
+	assert.EqualError(t, checkUnfreeze(acc, params, 0, 1000000, core.ResourceCode_BANDWIDTH),
+		"stake operation not allowed: too many pending unfreezes, withdraw expired ones first")
+}
+
+func TestMaxFreezeAmount(t *testing.T) {
+	assert.Equal(t, int64(4000000), maxFreezeAmount(5000000, 0))
+	assert.Equal(t, int64(3720000), maxFreezeAmount(5000000, 280000))
+	assert.Equal(t, int64(1000000), maxFreezeAmount(2000000, 0))
+	assert.Equal(t, int64(0), maxFreezeAmount(1999999, 0))
+	assert.Equal(t, int64(0), maxFreezeAmount(2000000, 1))
+}
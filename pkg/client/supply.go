@@ -0,0 +1,151 @@
+package client
+
+import (
+	"bytes"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// BlackHoleAddress receives fees burnt before the burn counter was introduced
+const BlackHoleAddress = "T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb"
+
+const (
+	// proposalWitnessPayPerBlock proposal parameter ID of getWitnessPayPerBlock
+	proposalWitnessPayPerBlock int64 = 5
+	// proposalWitness127PayPerBlock proposal parameter ID of getWitness127PayPerBlock
+	proposalWitness127PayPerBlock int64 = 31
+)
+
+const (
+	// genesisWitnessPay SUN minted per block for its producer at genesis
+	genesisWitnessPay = 32000000
+	// genesisWitness127Pay SUN minted per block for the vote rewards at genesis
+	genesisWitness127Pay = 16000000
+)
+
+// Supply TRX supply figures in SUN
+type Supply struct {
+	Genesis     int64 `json:"genesis"`
+	Minted      int64 `json:"minted"`
+	Burned      int64 `json:"burned"`
+	Total       int64 `json:"total"`
+	Frozen      int64 `json:"frozen"`
+	Circulating int64 `json:"circulating"`
+}
+
+// GetSupply estimates TRX supply from on-chain data. It is an approximation:
+//   - genesis is the genesis block allocations, without the black hole one
+//     which starts far below zero on mainnet
+//   - minted is the block and vote rewards up to the head block, at the
+//     rates set by approved proposals
+//   - burned TRX is the node burn counter plus what the black hole address
+//     received since genesis
+//   - frozen TRX is the network staked weight, rounded down to whole TRX
+func (g *GrpcClient) GetSupply() (*Supply, error) {
+	genesis, err := g.GetBlockByNum(0)
+	if err != nil {
+		return nil, err
+	}
+	supply := &Supply{}
+	genesisBlackHole, err := genesisAllocations(genesis, supply)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := g.GetNowBlock()
+	if err != nil {
+		return nil, err
+	}
+	headNum := head.GetBlockHeader().GetRawData().GetNumber()
+	for _, reward := range []struct{ parameter, genesis int64 }{
+		{proposalWitnessPayPerBlock, genesisWitnessPay},
+		{proposalWitness127PayPerBlock, genesisWitness127Pay},
+	} {
+		changes, err := g.priceHistory(reward.parameter, reward.genesis)
+		if err != nil {
+			return nil, err
+		}
+		supply.Minted += mintedUntil(changes, headNum)
+	}
+
+	ctx, cancel := g.getContext()
+	defer cancel()
+	burn, err := g.Client.GetBurnTrx(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return nil, err
+	}
+	supply.Burned = burn.GetNum()
+	if blackHole, err := g.GetAccount(BlackHoleAddress); err == nil {
+		supply.Burned += blackHole.GetBalance() - genesisBlackHole
+	} else if err != ErrAccountNotFound {
+		return nil, err
+	}
+
+	resources, err := g.GetAccountResource(BlackHoleAddress)
+	if err != nil {
+		return nil, err
+	}
+	supply.Frozen = (resources.GetTotalNetWeight() + resources.GetTotalEnergyWeight()) * 1000000
+
+	supply.Total = supply.Genesis + supply.Minted - supply.Burned
+	supply.Circulating = supply.Total - supply.Frozen
+	return supply, nil
+}
+
+// genesisAllocations sums the genesis block transfers in supply.Genesis,
+// leaving out and returning the black hole allocation
+func genesisAllocations(genesis *api.BlockExtention, supply *Supply) (int64, error) {
+	blackHole, err := common.DecodeCheck(BlackHoleAddress)
+	if err != nil {
+		return 0, err
+	}
+	var blackHoleAmount int64
+	for _, tx := range genesis.GetTransactions() {
+		for _, contract := range tx.GetTransaction().GetRawData().GetContract() {
+			if contract.GetType() != core.Transaction_Contract_TransferContract {
+				continue
+			}
+			transfer := &core.TransferContract{}
+			if err := contract.GetParameter().UnmarshalTo(transfer); err != nil {
+				return 0, err
+			}
+			if bytes.Equal(transfer.ToAddress, blackHole) {
+				blackHoleAmount += transfer.Amount
+				continue
+			}
+			supply.Genesis += transfer.Amount
+		}
+	}
+	return blackHoleAmount, nil
+}
+
+// mintedUntil SUN minted per block at the rates of changes, At holding the
+// block each rate took effect, from block 1 to head
+func mintedUntil(changes []priceChange, head int64) int64 {
+	var minted int64
+	for i, c := range changes {
+		from := c.At
+		if from < 1 {
+			from = 1
+		}
+		to := head
+		if i+1 < len(changes) && changes[i+1].At-1 < to {
+			to = changes[i+1].At - 1
+		}
+		if to >= from {
+			minted += (to - from + 1) * c.Price
+		}
+	}
+	return minted
+}
+
+// GetCirculatingSupply estimates the TRX circulating supply in SUN, see GetSupply for caveats
+func (g *GrpcClient) GetCirculatingSupply() (int64, error) {
+	supply, err := g.GetSupply()
+	if err != nil {
+		return 0, err
+	}
+	return supply.Circulating, nil
+}
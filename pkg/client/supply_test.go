@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// supplyWallet node answering the calls of GetSupply, others panic
+type supplyWallet struct {
+	api.WalletClient
+	genesis          *api.BlockExtention
+	blackHoleBalance int64
+}
+
+func (w *supplyWallet) GetBlockByNum2(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return w.genesis, nil
+}
+
+func (w *supplyWallet) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: 10}}}, nil
+}
+
+func (w *supplyWallet) ListProposals(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.ProposalList, error) {
+	return &api.ProposalList{}, nil
+}
+
+func (w *supplyWallet) GetBurnTrx(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.NumberMessage, error) {
+	return &api.NumberMessage{Num: 100000000}, nil
+}
+
+func (w *supplyWallet) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	return &core.Account{Address: in.Address, Balance: w.blackHoleBalance}, nil
+}
+
+func (w *supplyWallet) GetAccountResource(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*api.AccountResourceMessage, error) {
+	return &api.AccountResourceMessage{TotalNetWeight: 1000, TotalEnergyWeight: 2000}, nil
+}
+
+func genesisTransfer(t *testing.T, to string, amount int64) *api.TransactionExtention {
+	addr, err := common.DecodeCheck(to)
+	require.Nil(t, err)
+	param, err := anypb.New(&core.TransferContract{ToAddress: addr, Amount: amount})
+	require.Nil(t, err)
+	return &api.TransactionExtention{Transaction: &core.Transaction{RawData: &core.TransactionRaw{
+		Contract: []*core.Transaction_Contract{{Type: core.Transaction_Contract_TransferContract, Parameter: param}},
+	}}}
+}
+
+func TestGetSupply(t *testing.T) {
+	// mainnet genesis gives the black hole Long.MIN_VALUE
+	wallet := &supplyWallet{
+		genesis: &api.BlockExtention{Transactions: []*api.TransactionExtention{
+			genesisTransfer(t, "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", 10000000000),
+			genesisTransfer(t, BlackHoleAddress, math.MinInt64),
+			genesisTransfer(t, "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9", 5000000000),
+		}},
+		blackHoleBalance: math.MinInt64 + 300000000,
+	}
+	c := NewGrpcClient("")
+	c.Client = wallet
+
+	supply, err := c.GetSupply()
+	require.Nil(t, err)
+	assert.Equal(t, &Supply{
+		Genesis:     15000000000,
+		Minted:      10 * (genesisWitnessPay + genesisWitness127Pay),
+		Burned:      400000000,
+		Total:       15080000000,
+		Frozen:      3000000000,
+		Circulating: 12080000000,
+	}, supply)
+}
+
+func TestMintedUntil(t *testing.T) {
+	changes := []priceChange{{At: 0, Price: 32}, {At: 5, Price: 16}, {At: 20, Price: 8}}
+	assert.Equal(t, int64(4*32+6*16), mintedUntil(changes, 10))
+	assert.Equal(t, int64(4*32+15*16+2*8), mintedUntil(changes, 21))
+	assert.Zero(t, mintedUntil(changes, 0))
+}
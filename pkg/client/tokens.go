@@ -0,0 +1,83 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/account"
+)
+
+// SetTRC20Tokens sets the TRC20 contracts checked by GetAccountTokens, nodes
+// do not index TRC20 holdings so they must be known in advance
+func (g *GrpcClient) SetTRC20Tokens(contracts []string) {
+	g.trc20Mu.Lock()
+	defer g.trc20Mu.Unlock()
+	g.trc20Tokens = append([]string{}, contracts...)
+}
+
+// getTRC20Tokens returns the contracts set with SetTRC20Tokens
+func (g *GrpcClient) getTRC20Tokens() []string {
+	g.trc20Mu.RLock()
+	defer g.trc20Mu.RUnlock()
+	return g.trc20Tokens
+}
+
+// GetAccountTokens returns the TRC10 assets held by addr, sorted by ID,
+// followed by its non zero balances of the contracts set with SetTRC20Tokens
+func (g *GrpcClient) GetAccountTokens(addr string) ([]account.TokenBalance, error) {
+	acc, err := g.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(acc.GetAssetV2()))
+	for id, amount := range acc.GetAssetV2() {
+		if amount > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	contracts := g.getTRC20Tokens()
+	tokens := make([]account.TokenBalance, 0, len(ids)+len(contracts))
+	for _, id := range ids {
+		asset, err := g.GetAssetIssueByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("asset %s: %v", id, err)
+		}
+		tokens = append(tokens, account.TokenBalance{
+			TokenID:  id,
+			Symbol:   string(asset.GetAbbr()),
+			Decimals: asset.GetPrecision(),
+			Balance:  big.NewInt(acc.GetAssetV2()[id]),
+			Type:     account.TokenTRC10,
+		})
+	}
+
+	for _, contract := range contracts {
+		balance, err := g.TRC20ContractBalance(addr, contract)
+		if err != nil {
+			return nil, fmt.Errorf("token %s: %v", contract, err)
+		}
+		if balance.Sign() == 0 {
+			continue
+		}
+		symbol, err := g.TRC20GetSymbol(contract)
+		if err != nil {
+			return nil, fmt.Errorf("token %s: %v", contract, err)
+		}
+		decimals, err := g.TRC20GetDecimals(contract)
+		if err != nil {
+			return nil, fmt.Errorf("token %s: %v", contract, err)
+		}
+		tokens = append(tokens, account.TokenBalance{
+			TokenID:  contract,
+			Symbol:   symbol,
+			Decimals: int32(decimals.Int64()),
+			Balance:  balance,
+			Type:     account.TokenTRC20,
+		})
+	}
+	return tokens, nil
+}
@@ -1,6 +1,9 @@
 package client
 
 import (
+	"unicode/utf8"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 )
@@ -16,3 +19,26 @@ func (g *GrpcClient) GetTransactionSignWeight(tx *core.Transaction) (*api.Transa
 	}
 	return result, nil
 }
+
+// GetMemoByTransactionId returns the memo (raw data field) of a transaction,
+// hasMemo is false when it is empty. Memos that are not valid UTF-8 are
+// returned as 0x prefixed HEX.
+func (g *GrpcClient) GetMemoByTransactionId(txID string) (memo string, hasMemo bool, err error) {
+	tx, err := g.GetTransactionByID(txID)
+	if err != nil {
+		return "", false, err
+	}
+	memo, hasMemo = transactionMemo(tx)
+	return memo, hasMemo, nil
+}
+
+func transactionMemo(tx *core.Transaction) (string, bool) {
+	data := tx.GetRawData().GetData()
+	if len(data) == 0 {
+		return "", false
+	}
+	if utf8.Valid(data) {
+		return string(data), true
+	}
+	return common.BytesToHexString(data), true
+}
@@ -0,0 +1,131 @@
+package transaction
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// trc20TransferSelector transfer(address,uint256)
+var trc20TransferSelector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+// Expectation describes what a transaction is supposed to do, empty fields
+// are not checked. For TriggerSmartContract To and Amount refer to the
+// TRC20 transfer(address,uint256) call data and Contract to the token. The
+// TRX and TRC10 sent along with a contract call are always checked, they
+// must be zero unless CallValue, CallTokenValue and CallTokenID say otherwise.
+type Expectation struct {
+	Type           core.Transaction_Contract_ContractType
+	Owner          string
+	To             string
+	Amount         *big.Int
+	Contract       string
+	AssetID        string
+	MaxFeeLimit    int64
+	CallValue      int64
+	CallTokenValue int64
+	CallTokenID    int64
+}
+
+// Assert checks tx holds a single contract matching expect, to be called
+// before signing a transaction built by someone else
+func Assert(tx *core.Transaction, expect Expectation) error {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) != 1 {
+		return fmt.Errorf("expected 1 contract, got %d", len(contracts))
+	}
+	contract := contracts[0]
+	if contract.Type != expect.Type {
+		return fmt.Errorf("expected %s, got %s", expect.Type.String(), contract.Type.String())
+	}
+	if expect.MaxFeeLimit > 0 && tx.GetRawData().GetFeeLimit() > expect.MaxFeeLimit {
+		return fmt.Errorf("fee limit %d above %d", tx.GetRawData().GetFeeLimit(), expect.MaxFeeLimit)
+	}
+
+	owner, err := contractOwner(contract)
+	if err != nil {
+		return err
+	}
+	if err = assertAddress("owner", owner, expect.Owner); err != nil {
+		return err
+	}
+
+	switch contract.Type {
+	case core.Transaction_Contract_TransferContract:
+		c := &core.TransferContract{}
+		if err = contract.GetParameter().UnmarshalTo(c); err != nil {
+			return err
+		}
+		if err = assertAddress("recipient", c.ToAddress, expect.To); err != nil {
+			return err
+		}
+		return assertAmount(big.NewInt(c.Amount), expect.Amount)
+	case core.Transaction_Contract_TransferAssetContract:
+		c := &core.TransferAssetContract{}
+		if err = contract.GetParameter().UnmarshalTo(c); err != nil {
+			return err
+		}
+		if len(expect.AssetID) > 0 && string(c.AssetName) != expect.AssetID {
+			return fmt.Errorf("expected asset %s, got %s", expect.AssetID, string(c.AssetName))
+		}
+		if err = assertAddress("recipient", c.ToAddress, expect.To); err != nil {
+			return err
+		}
+		return assertAmount(big.NewInt(c.Amount), expect.Amount)
+	case core.Transaction_Contract_TriggerSmartContract:
+		c := &core.TriggerSmartContract{}
+		if err = contract.GetParameter().UnmarshalTo(c); err != nil {
+			return err
+		}
+		if err = assertAddress("contract", c.ContractAddress, expect.Contract); err != nil {
+			return err
+		}
+		if c.CallValue != expect.CallValue {
+			return fmt.Errorf("expected call value %d, got %d", expect.CallValue, c.CallValue)
+		}
+		if c.CallTokenValue != expect.CallTokenValue || c.TokenId != expect.CallTokenID {
+			return fmt.Errorf("expected call token value %d of token %d, got %d of token %d",
+				expect.CallTokenValue, expect.CallTokenID, c.CallTokenValue, c.TokenId)
+		}
+		if len(expect.To) == 0 && expect.Amount == nil {
+			return nil
+		}
+		if len(c.Data) != 68 || !bytes.Equal(c.Data[:4], trc20TransferSelector) {
+			return fmt.Errorf("expected TRC20 transfer call")
+		}
+		to := append([]byte{0x41}, c.Data[16:36]...)
+		if err = assertAddress("recipient", to, expect.To); err != nil {
+			return err
+		}
+		return assertAmount(new(big.Int).SetBytes(c.Data[36:68]), expect.Amount)
+	default:
+		if len(expect.To) > 0 || expect.Amount != nil || len(expect.Contract) > 0 || len(expect.AssetID) > 0 {
+			return fmt.Errorf("can not check recipient or amount of %s", contract.Type.String())
+		}
+	}
+	return nil
+}
+
+func assertAddress(name string, got []byte, expected string) error {
+	if len(expected) == 0 {
+		return nil
+	}
+	want, err := common.DecodeCheck(expected)
+	if err != nil {
+		return fmt.Errorf("invalid expected %s: %v", name, err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("expected %s %s, got %s", name, expected, common.EncodeCheck(got))
+	}
+	return nil
+}
+
+func assertAmount(got, expected *big.Int) error {
+	if expected != nil && got.Cmp(expected) != 0 {
+		return fmt.Errorf("expected amount %s, got %s", expected.String(), got.String())
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestAssertTransfer(t *testing.T) {
+	tx, err := BuildTransfer("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		1000000, testRefBlock, time.UnixMilli(1700000060000))
+	require.Nil(t, err)
+
+	expect := Expectation{
+		Type:   core.Transaction_Contract_TransferContract,
+		Owner:  "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b",
+		To:     "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		Amount: big.NewInt(1000000),
+	}
+	assert.Nil(t, Assert(tx, expect))
+
+	wrong := expect
+	wrong.Amount = big.NewInt(999999)
+	assert.EqualError(t, Assert(tx, wrong), "expected amount 999999, got 1000000")
+
+	wrong = expect
+	wrong.To = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
+	assert.EqualError(t, Assert(tx, wrong),
+		"expected recipient TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b, got TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+
+	wrong = expect
+	wrong.Type = core.Transaction_Contract_TriggerSmartContract
+	assert.Error(t, Assert(tx, wrong))
+}
+
+func TestAssertTRC20Transfer(t *testing.T) {
+	owner, _ := common.DecodeCheck("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	token, _ := common.DecodeCheck("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	to, _ := common.DecodeCheck("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+
+	data := append([]byte{}, trc20TransferSelector...)
+	data = append(data, common.LeftPadBytes(to[1:], 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(5000000).Bytes(), 32)...)
+	param, err := anypb.New(&core.TriggerSmartContract{
+		OwnerAddress:    owner,
+		ContractAddress: token,
+		Data:            data,
+	})
+	require.Nil(t, err)
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		FeeLimit: 100000000,
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_TriggerSmartContract,
+			Parameter: param,
+		}},
+	}}
+
+	expect := Expectation{
+		Type:        core.Transaction_Contract_TriggerSmartContract,
+		Contract:    "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		To:          "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		Amount:      big.NewInt(5000000),
+		MaxFeeLimit: 100000000,
+	}
+	assert.Nil(t, Assert(tx, expect))
+
+	expect.MaxFeeLimit = 10000000
+	assert.EqualError(t, Assert(tx, expect), "fee limit 100000000 above 10000000")
+}
+
+func TestAssertCallValue(t *testing.T) {
+	owner, _ := common.DecodeCheck("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	contract, _ := common.DecodeCheck("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	build := func(trigger *core.TriggerSmartContract) *core.Transaction {
+		trigger.OwnerAddress = owner
+		trigger.ContractAddress = contract
+		param, err := anypb.New(trigger)
+		require.Nil(t, err)
+		return &core.Transaction{RawData: &core.TransactionRaw{
+			Contract: []*core.Transaction_Contract{{
+				Type:      core.Transaction_Contract_TriggerSmartContract,
+				Parameter: param,
+			}},
+		}}
+	}
+	expect := Expectation{
+		Type:     core.Transaction_Contract_TriggerSmartContract,
+		Contract: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+	}
+
+	assert.Nil(t, Assert(build(&core.TriggerSmartContract{}), expect))
+	assert.EqualError(t, Assert(build(&core.TriggerSmartContract{CallValue: 1000000}), expect),
+		"expected call value 0, got 1000000")
+	assert.EqualError(t, Assert(build(&core.TriggerSmartContract{CallTokenValue: 5, TokenId: 1002000}), expect),
+		"expected call token value 0 of token 0, got 5 of token 1002000")
+
+	expect.CallValue = 1000000
+	assert.Nil(t, Assert(build(&core.TriggerSmartContract{CallValue: 1000000}), expect))
+	expect.CallTokenValue, expect.CallTokenID = 5, 1002000
+	assert.Nil(t, Assert(build(&core.TriggerSmartContract{CallValue: 1000000, CallTokenValue: 5, TokenId: 1002000}), expect))
+}
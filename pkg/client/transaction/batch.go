@@ -0,0 +1,189 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// ErrNotSent is the error of batch results whose transfer was never
+// attempted because the batch stopped first
+var ErrNotSent = errors.New("transfer not sent, batch stopped")
+
+// Recipient of a single transfer inside a batch
+type Recipient struct {
+	Address string
+	Amount  int64
+}
+
+// BatchResult outcome of a single transfer inside a batch
+type BatchResult struct {
+	Recipient Recipient
+	TxID      string
+	Receipt   *core.TransactionInfo
+	Err       error
+}
+
+// Batch sends many transactions signed by the same account
+type Batch struct {
+	client     *client.GrpcClient
+	ks         *keystore.KeyStore
+	account    *keystore.Account
+	passphrase string
+	options    []func(*Controller)
+	budget     feeBudget
+}
+
+// NewBatch initializes a Batch, options are applied to every transaction controller
+func NewBatch(
+	client *client.GrpcClient,
+	senderKs *keystore.KeyStore,
+	senderAcct *keystore.Account,
+	passphrase string,
+	options ...func(*Controller),
+) *Batch {
+	return &Batch{
+		client:     client,
+		ks:         senderKs,
+		account:    senderAcct,
+		passphrase: passphrase,
+		options:    options,
+	}
+}
+
+// SetFeeBudget stops the batch once fees paid, in SUN, go over limit.
+// Transfers already in flight still complete and are counted.
+func (b *Batch) SetFeeBudget(limit int64) {
+	b.budget.setLimit(limit)
+}
+
+// Spent total fees in SUN paid by the batch transfers so far
+func (b *Batch) Spent() int64 {
+	return b.budget.total()
+}
+
+// BatchTransfer sends TRX to every recipient and returns results in input
+// order, recipients left out once the batch stopped have ErrNotSent
+func (b *Batch) BatchTransfer(ctx context.Context, recipients []Recipient, concurrency int) ([]BatchResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	index := make(map[Recipient][]int)
+	recipientChan := make(chan Recipient)
+	resultChan := make(chan BatchResult)
+	for i, r := range recipients {
+		index[r] = append(index[r], i)
+	}
+
+	go func() {
+		defer close(recipientChan)
+		for _, r := range recipients {
+			select {
+			case recipientChan <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var streamErr error
+	done := make(chan struct{})
+	go func() {
+		streamErr = b.BatchTransferStream(ctx, recipientChan, resultChan, concurrency)
+		close(done)
+	}()
+
+	results := make([]BatchResult, len(recipients))
+	for result := range resultChan {
+		pending := index[result.Recipient]
+		results[pending[0]] = result
+		index[result.Recipient] = pending[1:]
+	}
+	<-done
+	for r, pending := range index {
+		for _, i := range pending {
+			results[i] = BatchResult{Recipient: r, Err: ErrNotSent}
+		}
+	}
+	return results, streamErr
+}
+
+// BatchTransferStream reads recipients until recipientChan is closed and sends
+// one result per recipient to resultChan, which is closed on return and must
+// be read until then. At most concurrency transfers are in flight and a slow
+// reader of resultChan holds workers back. Cancelling ctx stops the batch
+// without starting new transfers, as does going over the fee budget, which
+// returns ErrFeeBudgetExceeded. Transfers already started always report their
+// result, recipients read after the batch stopped get ErrNotSent, so
+// recipientChan must still be closed.
+func (b *Batch) BatchTransferStream(
+	ctx context.Context,
+	recipientChan <-chan Recipient,
+	resultChan chan<- BatchResult,
+	concurrency int,
+) error {
+	defer close(resultChan)
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency: %d", concurrency)
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var exceeded atomic.Bool
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range recipientChan {
+				if ctx.Err() != nil {
+					resultChan <- BatchResult{Recipient: r, Err: ErrNotSent}
+					continue
+				}
+				result := b.transfer(r)
+				over := result.Receipt != nil && b.budget.add(result.Receipt)
+				// delivered even once stopped, the transfer may have been sent
+				resultChan <- result
+				if over {
+					exceeded.Store(true)
+					cancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if exceeded.Load() {
+		return fmt.Errorf("%w: spent %d SUN", ErrFeeBudgetExceeded, b.Spent())
+	}
+	return parent.Err()
+}
+
+func (b *Batch) transfer(r Recipient) BatchResult {
+	result := BatchResult{Recipient: r}
+	tx, err := b.client.Transfer(b.account.Address.String(), r.Address, r.Amount)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.TxID = common.BytesToHexString(tx.GetTxid())
+
+	options := append([]func(*Controller){}, b.options...)
+	options = append(options, WithPassphrase(b.passphrase))
+	ctrlr := NewController(b.client, b.ks, b.account, tx.Transaction, options...)
+	if err = ctrlr.ExecuteTransaction(); err != nil {
+		result.Err = err
+		return result
+	}
+	result.Receipt = ctrlr.Receipt
+	result.Err = ctrlr.GetResultError()
+	return result
+}
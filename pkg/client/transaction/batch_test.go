@@ -0,0 +1,85 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// cancellingWallet cancels the batch while the first transfer is built
+type cancellingWallet struct {
+	api.WalletClient
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (w *cancellingWallet) CreateTransaction2(ctx context.Context, in *core.TransferContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	w.calls++
+	w.cancel()
+	return nil, errors.New("node rejected transfer")
+}
+
+func testRecipients() []Recipient {
+	return []Recipient{
+		{Address: "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9", Amount: 1},
+		{Address: "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9", Amount: 2},
+		{Address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Amount: 3},
+	}
+}
+
+func TestBatchTransferCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	batch := NewBatch(nil, nil, nil, "")
+	results, err := batch.BatchTransfer(ctx, testRecipients(), 2)
+	assert.Equal(t, context.Canceled, err)
+	require.Len(t, results, 3)
+	for i, result := range results {
+		assert.Equal(t, testRecipients()[i], result.Recipient)
+		assert.Equal(t, ErrNotSent, result.Err)
+	}
+}
+
+func TestBatchTransferStreamCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	owner, err := address.Base58ToAddress("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	require.Nil(t, err)
+	wallet := &cancellingWallet{cancel: cancel}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+	batch := NewBatch(c, nil, &keystore.Account{Address: owner}, "")
+
+	recipientChan := make(chan Recipient, 3)
+	for _, r := range testRecipients() {
+		recipientChan <- r
+	}
+	close(recipientChan)
+	resultChan := make(chan BatchResult)
+	done := make(chan error)
+	go func() {
+		done <- batch.BatchTransferStream(ctx, recipientChan, resultChan, 1)
+	}()
+
+	results := make([]BatchResult, 0)
+	for result := range resultChan {
+		results = append(results, result)
+	}
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Equal(t, 1, wallet.calls)
+	require.Len(t, results, 3)
+	// the transfer in flight when cancelled still reports its outcome
+	assert.EqualError(t, results[0].Err, "node rejected transfer")
+	assert.Equal(t, ErrNotSent, results[1].Err)
+	assert.Equal(t, ErrNotSent, results[2].Err)
+}
@@ -0,0 +1,39 @@
+package transaction
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// ErrFeeBudgetExceeded is returned when the fees paid by a batch or queue go
+// over the budget set with SetFeeBudget
+var ErrFeeBudgetExceeded = errors.New("fee budget exceeded")
+
+// feeBudget sums fees from receipts, a zero limit means no budget
+type feeBudget struct {
+	mu    sync.Mutex
+	limit int64
+	spent int64
+}
+
+// add counts the receipt fee and reports whether spending is now over limit
+func (f *feeBudget) add(receipt *core.TransactionInfo) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.spent += receipt.GetFee()
+	return f.limit > 0 && f.spent > f.limit
+}
+
+func (f *feeBudget) setLimit(limit int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.limit = limit
+}
+
+func (f *feeBudget) total() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.spent
+}
@@ -0,0 +1,22 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeBudget(t *testing.T) {
+	var unlimited feeBudget
+	assert.False(t, unlimited.add(&core.TransactionInfo{Fee: 1000000000}))
+	assert.Equal(t, int64(1000000000), unlimited.total())
+
+	var budget feeBudget
+	budget.setLimit(1000000)
+	assert.False(t, budget.add(&core.TransactionInfo{Fee: 600000}))
+	assert.False(t, budget.add(nil))
+	assert.False(t, budget.add(&core.TransactionInfo{Fee: 400000}))
+	assert.True(t, budget.add(&core.TransactionInfo{Fee: 1}))
+	assert.Equal(t, int64(1000001), budget.total())
+}
@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// RefBlock is the block a transaction references, a transaction is only
+// valid on the chain that contains this block
+type RefBlock struct {
+	Number int64
+	Hash   []byte
+}
+
+// BuildTransfer creates an unsigned TRX transfer without contacting a node,
+// the same inputs always produce the same transaction
+func BuildTransfer(from, to string, amount int64, ref RefBlock, expiration time.Time) (*core.Transaction, error) {
+	var err error
+
+	contract := &core.TransferContract{}
+	if contract.OwnerAddress, err = common.DecodeCheck(from); err != nil {
+		return nil, err
+	}
+	if contract.ToAddress, err = common.DecodeCheck(to); err != nil {
+		return nil, err
+	}
+	contract.Amount = amount
+
+	param, err := anypb.New(contract)
+	if err != nil {
+		return nil, err
+	}
+	tx := &core.Transaction{
+		RawData: &core.TransactionRaw{
+			Contract: []*core.Transaction_Contract{{
+				Type:      core.Transaction_Contract_TransferContract,
+				Parameter: param,
+			}},
+		},
+	}
+	if err = setReference(tx, ref, expiration); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// setReference anchors tx to ref, following the node rules: the last two
+// bytes of the block number and bytes 8 to 16 of the block hash
+func setReference(tx *core.Transaction, ref RefBlock, expiration time.Time) error {
+	if len(ref.Hash) != 32 {
+		return fmt.Errorf("invalid reference block hash length: %d", len(ref.Hash))
+	}
+	number := make([]byte, 8)
+	binary.BigEndian.PutUint64(number, uint64(ref.Number))
+
+	tx.RawData.RefBlockBytes = number[6:8]
+	tx.RawData.RefBlockHash = common.CopyBytes(ref.Hash[8:16])
+	tx.RawData.Expiration = expiration.UnixMilli()
+	return nil
+}
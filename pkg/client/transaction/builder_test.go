@@ -0,0 +1,80 @@
+package transaction
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+var testRefBlock = RefBlock{
+	Number: 0x2a3b4c5d,
+	Hash:   bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 8),
+}
+
+func TestBuildTransfer(t *testing.T) {
+	expiration := time.UnixMilli(1700000060000)
+	tx, err := BuildTransfer("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		1000000, testRefBlock, expiration)
+	require.Nil(t, err)
+
+	raw := tx.GetRawData()
+	assert.Equal(t, []byte{0x4c, 0x5d}, raw.RefBlockBytes)
+	assert.Equal(t, testRefBlock.Hash[8:16], raw.RefBlockHash)
+	assert.Equal(t, int64(1700000060000), raw.Expiration)
+	assert.Empty(t, tx.Signature)
+
+	require.Len(t, raw.Contract, 1)
+	assert.Equal(t, core.Transaction_Contract_TransferContract, raw.Contract[0].Type)
+	contract := &core.TransferContract{}
+	require.Nil(t, raw.Contract[0].Parameter.UnmarshalTo(contract))
+	assert.Equal(t, int64(1000000), contract.Amount)
+	assert.Equal(t, "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", common.EncodeCheck(contract.OwnerAddress))
+	assert.Equal(t, "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9", common.EncodeCheck(contract.ToAddress))
+}
+
+func TestBuildTransferDeterministic(t *testing.T) {
+	expiration := time.UnixMilli(1700000060000)
+	build := func() []byte {
+		tx, err := BuildTransfer("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+			1000000, testRefBlock, expiration)
+		require.Nil(t, err)
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(tx.GetRawData())
+		require.Nil(t, err)
+		return b
+	}
+	assert.Equal(t, build(), build())
+}
+
+func TestBuildTransferInvalid(t *testing.T) {
+	_, err := BuildTransfer("invalid", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9", 1, testRefBlock, time.Now())
+	assert.NotNil(t, err)
+
+	_, err = BuildTransfer("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9", 1,
+		RefBlock{Number: 1, Hash: []byte{0x01}}, time.Now())
+	assert.NotNil(t, err)
+}
+
+func TestWithRefBlock(t *testing.T) {
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		RefBlockBytes: []byte{0x00, 0x01},
+		RefBlockHash:  make([]byte, 8),
+		Expiration:    1700000060000,
+	}}
+	ctrlr := NewController(nil, nil, nil, tx, WithRefBlock(testRefBlock))
+	ctrlr.setRefBlock()
+	require.Nil(t, ctrlr.executionError)
+	assert.Equal(t, []byte{0x4c, 0x5d}, tx.RawData.RefBlockBytes)
+	assert.Equal(t, testRefBlock.Hash[8:16], tx.RawData.RefBlockHash)
+	assert.Equal(t, int64(1700000060000), tx.RawData.Expiration)
+
+	tx.Signature = [][]byte{make([]byte, 65)}
+	ctrlr = NewController(nil, nil, nil, tx, WithRefBlock(testRefBlock))
+	ctrlr.setRefBlock()
+	assert.NotNil(t, ctrlr.executionError)
+}
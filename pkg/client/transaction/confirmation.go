@@ -0,0 +1,39 @@
+package transaction
+
+import (
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// Confirmation outcome of a transaction, a transaction can be included in a
+// block (TxConfirmed) while the contract call it carries reverted or ran out
+// of energy (ContractSucceeded false), fees are charged in both cases
+type Confirmation struct {
+	TxConfirmed       bool
+	ContractSucceeded bool
+	BlockNumber       int64
+	ContractResult    core.Transaction_ResultContractResult
+	Message           string
+}
+
+// NewConfirmation reads a receipt, TxConfirmed comes from the block number and
+// ContractSucceeded from the receipt result and contractRet. Contracts that
+// do not run the VM report contractRet DEFAULT and succeed with the transaction.
+func NewConfirmation(info *core.TransactionInfo) Confirmation {
+	c := Confirmation{
+		TxConfirmed:    info.GetBlockNumber() > 0,
+		BlockNumber:    info.GetBlockNumber(),
+		ContractResult: info.GetReceipt().GetResult(),
+		Message:        string(info.GetResMessage()),
+	}
+	contractRet := c.ContractResult
+	c.ContractSucceeded = c.TxConfirmed &&
+		info.GetResult() == core.TransactionInfo_SUCESS &&
+		(contractRet == core.Transaction_Result_SUCCESS || contractRet == core.Transaction_Result_DEFAULT)
+	return c
+}
+
+// Confirmation returns the transaction outcome, TxConfirmed is false when no
+// confirmation wait time was set or the transaction was not found in time
+func (C *Controller) Confirmation() Confirmation {
+	return NewConfirmation(C.Receipt)
+}
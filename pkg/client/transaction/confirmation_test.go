@@ -0,0 +1,36 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfirmation(t *testing.T) {
+	pending := NewConfirmation(&core.TransactionInfo{Receipt: &core.ResourceReceipt{}})
+	assert.False(t, pending.TxConfirmed)
+	assert.False(t, pending.ContractSucceeded)
+
+	transfer := NewConfirmation(&core.TransactionInfo{BlockNumber: 10, Receipt: &core.ResourceReceipt{}})
+	assert.True(t, transfer.TxConfirmed)
+	assert.True(t, transfer.ContractSucceeded)
+
+	reverted := NewConfirmation(&core.TransactionInfo{
+		BlockNumber: 10,
+		Result:      core.TransactionInfo_FAILED,
+		ResMessage:  []byte("REVERT opcode executed"),
+		Receipt:     &core.ResourceReceipt{Result: core.Transaction_Result_REVERT},
+	})
+	assert.True(t, reverted.TxConfirmed)
+	assert.False(t, reverted.ContractSucceeded)
+	assert.Equal(t, core.Transaction_Result_REVERT, reverted.ContractResult)
+	assert.Equal(t, "REVERT opcode executed", reverted.Message)
+
+	outOfEnergy := NewConfirmation(&core.TransactionInfo{
+		BlockNumber: 10,
+		Receipt:     &core.ResourceReceipt{Result: core.Transaction_Result_OUT_OF_ENERGY},
+	})
+	assert.True(t, outOfEnergy.TxConfirmed)
+	assert.False(t, outOfEnergy.ContractSucceeded)
+}
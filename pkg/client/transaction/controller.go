@@ -1,11 +1,13 @@
 package transaction
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
@@ -13,17 +15,27 @@ import (
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	proto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var (
 	// ErrBadTransactionParam is returned when invalid params are given to the
 	// controller upon execution of a transaction.
 	ErrBadTransactionParam = errors.New("transaction has bad parameters")
+	// ErrInsufficientBalance is returned when the signer balance does not cover
+	// the call value set with WithCallValue plus the estimated energy fee
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrRefBlockMismatch is returned when the reference block set with
+	// WithRefBlock is not part of the connected network, the transaction
+	// would be rejected as signed for another chain
+	ErrRefBlockMismatch = errors.New("reference block not found on the connected network")
 )
 
 type sender struct {
-	ks      *keystore.KeyStore
-	account *keystore.Account
+	ks            *keystore.KeyStore
+	account       *keystore.Account
+	passphrase    string
+	usePassphrase bool
 }
 
 // Controller drives the transaction signing process
@@ -36,12 +48,17 @@ type Controller struct {
 	Behavior       behavior
 	Result         *api.Return
 	Receipt        *core.TransactionInfo
+	SignWeight     *api.TransactionSignWeight
 }
 
 type behavior struct {
 	DryRun               bool
 	SigningImpl          SignerImpl
 	ConfirmationWaitTime uint32
+	PermissionID         int32
+	MultiSig             bool
+	CallValue            int64
+	RefBlock             *RefBlock
 }
 
 // NewController initializes a Controller, caller can control behavior via options
@@ -61,8 +78,11 @@ func NewController(
 			ks:      senderKs,
 			account: senderAcct,
 		},
-		tx:       tx,
-		Behavior: behavior{false, Software, 0},
+		tx: tx,
+		Behavior: behavior{
+			DryRun:      false,
+			SigningImpl: Software,
+		},
 	}
 	for _, option := range options {
 		option(ctrlr)
@@ -70,12 +90,243 @@ func NewController(
 	return ctrlr
 }
 
+// WithPassphrase signs with the account passphrase instead of the unlocked key,
+// the keystore drops an unlocked key after its first signature so this is
+// required when one account signs several transactions
+func WithPassphrase(passphrase string) func(*Controller) {
+	return func(C *Controller) {
+		C.sender.passphrase = passphrase
+		C.sender.usePassphrase = true
+	}
+}
+
+// WithCustomPermission signs the transaction under the given account permission
+// (0 owner, 1 witness, 2+ active) instead of the owner permission
+func WithCustomPermission(permissionID int32) func(*Controller) {
+	return func(C *Controller) {
+		C.Behavior.PermissionID = permissionID
+	}
+}
+
+// WithMultiSig adds the signature to a multi signature transaction and only
+// broadcasts it once the permission threshold is met, otherwise the partially
+// signed transaction is kept for the next signer, see ThresholdMet
+func WithMultiSig() func(*Controller) {
+	return func(C *Controller) {
+		C.Behavior.MultiSig = true
+	}
+}
+
+// WithCallValue sends trxAmount SUN along with a TriggerSmartContract call to
+// a payable method. The signer balance must cover it plus the estimated
+// energy fee, see GrpcClient.EstimateCallFee.
+func WithCallValue(trxAmount int64) func(*Controller) {
+	return func(C *Controller) {
+		C.Behavior.CallValue = trxAmount
+	}
+}
+
+// WithRefBlock references ref instead of the head block the node used when
+// building the transaction, keeping its expiration. The transaction is then
+// only valid on a chain containing ref, e.g. to pin it to a fork.
+func WithRefBlock(ref RefBlock) func(*Controller) {
+	return func(C *Controller) {
+		C.Behavior.RefBlock = &ref
+	}
+}
+
+func (C *Controller) setRefBlock() {
+	if C.executionError != nil || C.Behavior.RefBlock == nil {
+		return
+	}
+	if C.tx.GetRawData() == nil {
+		C.executionError = ErrBadTransactionParam
+		return
+	}
+	if len(C.tx.Signature) > 0 {
+		C.executionError = fmt.Errorf("can not change reference block of a signed transaction")
+		return
+	}
+	C.executionError = setReference(C.tx, *C.Behavior.RefBlock, time.UnixMilli(C.tx.RawData.Expiration))
+}
+
+// verifyRefBlock checks the reference block given with WithRefBlock belongs
+// to the connected node chain before anything is signed over it
+func (C *Controller) verifyRefBlock() {
+	if C.executionError != nil || C.Behavior.RefBlock == nil {
+		return
+	}
+	ref := C.Behavior.RefBlock
+	block, err := C.client.GetBlockByNum(ref.Number)
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	if !bytes.Equal(block.GetBlockid(), ref.Hash) {
+		C.executionError = fmt.Errorf("%w: block %d has hash %s", ErrRefBlockMismatch,
+			ref.Number, common.BytesToHexString(block.GetBlockid()))
+	}
+}
+
+func (C *Controller) setCallValue() {
+	if C.executionError != nil || C.Behavior.CallValue == 0 {
+		return
+	}
+	if C.Behavior.CallValue < 0 {
+		C.executionError = fmt.Errorf("invalid call value: %d", C.Behavior.CallValue)
+		return
+	}
+	contracts := C.tx.GetRawData().GetContract()
+	if len(contracts) != 1 || contracts[0].Type != core.Transaction_Contract_TriggerSmartContract {
+		C.executionError = fmt.Errorf("call value requires a TriggerSmartContract transaction")
+		return
+	}
+	trigger := &core.TriggerSmartContract{}
+	if err := contracts[0].GetParameter().UnmarshalTo(trigger); err != nil {
+		C.executionError = err
+		return
+	}
+	if trigger.CallValue != C.Behavior.CallValue {
+		if len(C.tx.Signature) > 0 {
+			C.executionError = fmt.Errorf("can not change call value of a signed transaction")
+			return
+		}
+		trigger.CallValue = C.Behavior.CallValue
+		param, err := anypb.New(trigger)
+		if err != nil {
+			C.executionError = err
+			return
+		}
+		contracts[0].Parameter = param
+	}
+
+	acc, err := C.client.GetAccount(address.Address(trigger.OwnerAddress).String())
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	fee, err := C.client.EstimateCallFee(trigger)
+	if err != nil {
+		C.executionError = fmt.Errorf("estimate fee: %v", err)
+		return
+	}
+	if acc.Balance < C.Behavior.CallValue+fee {
+		C.executionError = fmt.Errorf("%w: %d SUN available, call value %d plus estimated fee %d required",
+			ErrInsufficientBalance, acc.Balance, C.Behavior.CallValue, fee)
+	}
+}
+
+func (C *Controller) setPermission() {
+	if C.executionError != nil || C.Behavior.PermissionID == 0 {
+		return
+	}
+	contracts := C.tx.GetRawData().GetContract()
+	if len(contracts) == 0 {
+		C.executionError = ErrBadTransactionParam
+		return
+	}
+	owner, err := contractOwner(contracts[0])
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	acc, err := C.client.GetAccount(address.Address(owner).String())
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	permission, err := findPermission(acc, C.Behavior.PermissionID)
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	if permission.Type == core.Permission_Active && !operationAllowed(permission.Operations, contracts[0].Type) {
+		C.executionError = fmt.Errorf("permission %d does not allow %s",
+			permission.Id, contracts[0].Type.String())
+		return
+	}
+	var weight int64
+	for _, key := range permission.Keys {
+		if bytes.Equal(key.Address, C.sender.account.Address) {
+			weight = key.Weight
+			break
+		}
+	}
+	if weight == 0 {
+		C.executionError = fmt.Errorf("signer %s is not a key of permission %d",
+			C.sender.account.Address.String(), permission.Id)
+		return
+	}
+	if len(C.tx.Signature) > 0 && contracts[0].PermissionId != permission.Id {
+		C.executionError = fmt.Errorf("transaction already signed under permission %d",
+			contracts[0].PermissionId)
+		return
+	}
+	if weight < permission.Threshold && !C.Behavior.MultiSig {
+		C.executionError = fmt.Errorf("signer weight %d is below permission %d threshold %d",
+			weight, permission.Id, permission.Threshold)
+		return
+	}
+	contracts[0].PermissionId = permission.Id
+}
+
+func findPermission(acc *core.Account, id int32) (*core.Permission, error) {
+	switch id {
+	case 0:
+		if acc.OwnerPermission != nil {
+			return acc.OwnerPermission, nil
+		}
+	case 1:
+		if acc.WitnessPermission != nil {
+			return acc.WitnessPermission, nil
+		}
+	default:
+		for _, p := range acc.ActivePermission {
+			if p.Id == id {
+				return p, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("permission %d not found", id)
+}
+
+// operationAllowed checks the contract type bit of an active permission operations bitmap
+func operationAllowed(operations []byte, contractType core.Transaction_Contract_ContractType) bool {
+	i := int(contractType)
+	if i/8 >= len(operations) {
+		return false
+	}
+	return operations[i/8]&(1<<uint(i%8)) != 0
+}
+
+// contractOwner extracts owner_address from any contract parameter
+func contractOwner(contract *core.Transaction_Contract) ([]byte, error) {
+	msg, err := contract.GetParameter().UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	field := msg.ProtoReflect().Descriptor().Fields().ByName("owner_address")
+	if field == nil {
+		return nil, fmt.Errorf("%s has no owner address", contract.Type.String())
+	}
+	return msg.ProtoReflect().Get(field).Bytes(), nil
+}
+
 func (C *Controller) signTxForSending() {
 	if C.executionError != nil {
 		return
 	}
-	signedTransaction, err :=
-		C.sender.ks.SignTx(*C.sender.account, C.tx)
+	var (
+		signedTransaction *core.Transaction
+		err               error
+	)
+	if C.sender.usePassphrase {
+		signedTransaction, err =
+			C.sender.ks.SignTxWithPassphrase(*C.sender.account, C.sender.passphrase, C.tx)
+	} else {
+		signedTransaction, err =
+			C.sender.ks.SignTx(*C.sender.account, C.tx)
+	}
 	if err != nil {
 		C.executionError = err
 		return
@@ -109,6 +360,42 @@ func (C *Controller) hardwareSignTxForSending() {
 	C.tx.Signature = append(C.tx.Signature, signature)
 }
 
+// checkSignWeight asks the node whether the signatures collected so far
+// reach the permission threshold
+func (C *Controller) checkSignWeight() {
+	if C.executionError != nil || !C.Behavior.MultiSig {
+		return
+	}
+	signWeight, err := C.client.GetTransactionSignWeight(C.tx)
+	if err != nil {
+		C.executionError = err
+		return
+	}
+	C.SignWeight = signWeight
+	switch signWeight.GetResult().GetCode() {
+	case api.TransactionSignWeight_Result_ENOUGH_PERMISSION,
+		api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION:
+	default:
+		C.executionError = fmt.Errorf("sign weight %s: %s",
+			signWeight.GetResult().GetCode().String(), signWeight.GetResult().GetMessage())
+	}
+}
+
+// ThresholdMet reports whether the transaction carries enough signature weight
+// to be broadcast, always true unless WithMultiSig is used
+func (C *Controller) ThresholdMet() bool {
+	if !C.Behavior.MultiSig {
+		return true
+	}
+	return C.SignWeight.GetResult().GetCode() == api.TransactionSignWeight_Result_ENOUGH_PERMISSION
+}
+
+// Transaction returns the transaction, signed once ExecuteTransaction ran,
+// to be handed to the next signer when ThresholdMet is false
+func (C *Controller) Transaction() *core.Transaction {
+	return C.tx
+}
+
 // TransactionHash extract hash from TX
 func (C *Controller) TransactionHash() (string, error) {
 	rawData, err := C.GetRawData()
@@ -125,7 +412,7 @@ func (C *Controller) txConfirmation() {
 	if C.executionError != nil || C.Behavior.DryRun {
 		return
 	}
-	if C.Behavior.ConfirmationWaitTime > 0 {
+	if C.Behavior.ConfirmationWaitTime > 0 && C.ThresholdMet() {
 		txHash, err := C.TransactionHash()
 		if err != nil {
 			C.executionError = fmt.Errorf("could not get tx hash")
@@ -167,12 +454,17 @@ func (C *Controller) GetResultError() error {
 // Each step in transaction creation, execution probably includes a mutation
 // Each becomes a no-op if executionError occurred in any previous step
 func (C *Controller) ExecuteTransaction() error {
+	C.setRefBlock()
+	C.verifyRefBlock()
+	C.setCallValue()
+	C.setPermission()
 	switch C.Behavior.SigningImpl {
 	case Software:
 		C.signTxForSending()
 	case Ledger:
 		C.hardwareSignTxForSending()
 	}
+	C.checkSignWeight()
 	C.sendSignedTx()
 	C.txConfirmation()
 	return C.executionError
@@ -184,7 +476,7 @@ func (C *Controller) GetRawData() ([]byte, error) {
 }
 
 func (C *Controller) sendSignedTx() {
-	if C.executionError != nil || C.Behavior.DryRun {
+	if C.executionError != nil || C.Behavior.DryRun || !C.ThresholdMet() {
 		return
 	}
 	result, err := C.client.Broadcast(C.tx)
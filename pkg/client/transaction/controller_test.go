@@ -0,0 +1,152 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// callWallet node answering the calls of setCallValue
+type callWallet struct {
+	api.WalletClient
+	balance     int64
+	energyUsed  int64
+	energyLimit int64
+}
+
+func (w *callWallet) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	return &core.Account{Address: in.Address, Balance: w.balance}, nil
+}
+
+func (w *callWallet) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, EnergyUsed: w.energyUsed}, nil
+}
+
+func (w *callWallet) GetAccountResource(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*api.AccountResourceMessage, error) {
+	return &api.AccountResourceMessage{EnergyLimit: w.energyLimit}, nil
+}
+
+func (w *callWallet) GetChainParameters(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*core.ChainParameters, error) {
+	return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+		{Key: "getEnergyFee", Value: 420},
+	}}, nil
+}
+
+func TestSetCallValue(t *testing.T) {
+	owner, err := address.Base58ToAddress("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	require.Nil(t, err)
+	contract, err := address.Base58ToAddress("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	require.Nil(t, err)
+	build := func() *core.Transaction {
+		param, err := anypb.New(&core.TriggerSmartContract{OwnerAddress: owner, ContractAddress: contract})
+		require.Nil(t, err)
+		return &core.Transaction{RawData: &core.TransactionRaw{
+			Contract: []*core.Transaction_Contract{{
+				Type:      core.Transaction_Contract_TriggerSmartContract,
+				Parameter: param,
+			}},
+			FeeLimit: 100000000,
+		}}
+	}
+
+	// 10000 energy burned at 420 SUN, far below the fee limit
+	wallet := &callWallet{balance: 5000000, energyUsed: 30000, energyLimit: 20000}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+	ctrlr := NewController(c, nil, nil, build(), WithCallValue(800000))
+	ctrlr.setCallValue()
+	require.Nil(t, ctrlr.executionError)
+	trigger := &core.TriggerSmartContract{}
+	require.Nil(t, ctrlr.tx.GetRawData().GetContract()[0].GetParameter().UnmarshalTo(trigger))
+	assert.Equal(t, int64(800000), trigger.CallValue)
+
+	wallet.energyLimit = 0
+	ctrlr = NewController(c, nil, nil, build(), WithCallValue(800000))
+	ctrlr.setCallValue()
+	assert.ErrorIs(t, ctrlr.executionError, ErrInsufficientBalance)
+	assert.Contains(t, ctrlr.executionError.Error(), "estimated fee 12600000")
+}
+
+// permissionWallet node serving an account with the given active permission
+type permissionWallet struct {
+	api.WalletClient
+	active *core.Permission
+}
+
+func (w *permissionWallet) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	return &core.Account{Address: in.Address, ActivePermission: []*core.Permission{w.active}}, nil
+}
+
+func TestSetPermission(t *testing.T) {
+	owner, err := address.Base58ToAddress("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	require.Nil(t, err)
+	signer, err := address.Base58ToAddress("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	require.Nil(t, err)
+	other, err := address.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.Nil(t, err)
+	transfer, err := BuildTransfer(owner.String(), other.String(), 1000000, testRefBlock, time.UnixMilli(1700000060000))
+	require.Nil(t, err)
+	// bit 1, TransferContract
+	transferOnly := []byte{0x02, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	// bit 31, TriggerSmartContract
+	triggerOnly := []byte{0, 0, 0, 0x80, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	tests := []struct {
+		name       string
+		permission int32
+		keys       []*core.Key
+		threshold  int64
+		operations []byte
+		multiSig   bool
+		err        string
+	}{
+		{name: "allowed", permission: 2, keys: []*core.Key{{Address: signer, Weight: 2}}, threshold: 2, operations: transferOnly},
+		{name: "key missing", permission: 2, keys: []*core.Key{{Address: other, Weight: 2}}, threshold: 1, operations: transferOnly,
+			err: "is not a key of permission 2"},
+		{name: "insufficient weight", permission: 2, keys: []*core.Key{{Address: signer, Weight: 1}, {Address: other, Weight: 1}}, threshold: 2,
+			operations: transferOnly, err: "signer weight 1 is below permission 2 threshold 2"},
+		{name: "insufficient weight, multi signature", permission: 2, keys: []*core.Key{{Address: signer, Weight: 1}, {Address: other, Weight: 1}},
+			threshold: 2, operations: transferOnly, multiSig: true},
+		{name: "operation not allowed", permission: 2, keys: []*core.Key{{Address: signer, Weight: 2}}, threshold: 1, operations: triggerOnly,
+			err: "does not allow TransferContract"},
+		{name: "permission missing", permission: 3, keys: []*core.Key{{Address: signer, Weight: 2}}, threshold: 1, operations: transferOnly,
+			err: "permission 3 not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := client.NewGrpcClient("")
+			c.Client = &permissionWallet{active: &core.Permission{
+				Type:       core.Permission_Active,
+				Id:         2,
+				Threshold:  tt.threshold,
+				Operations: tt.operations,
+				Keys:       tt.keys,
+			}}
+			options := []func(*Controller){WithCustomPermission(tt.permission)}
+			if tt.multiSig {
+				options = append(options, WithMultiSig())
+			}
+			tx := proto.Clone(transfer).(*core.Transaction)
+			ctrlr := NewController(c, nil, &keystore.Account{Address: signer}, tx, options...)
+			ctrlr.setPermission()
+			if tt.err != "" {
+				require.NotNil(t, ctrlr.executionError)
+				assert.Contains(t, ctrlr.executionError.Error(), tt.err)
+				return
+			}
+			require.Nil(t, ctrlr.executionError)
+			assert.Equal(t, int32(2), tx.GetRawData().GetContract()[0].GetPermissionId())
+		})
+	}
+}
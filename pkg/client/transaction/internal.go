@@ -0,0 +1,49 @@
+package transaction
+
+import (
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// InternalTx call or value transfer made by a contract while running a
+// transaction, as recorded in its receipt
+type InternalTx struct {
+	Hash   string
+	Caller string
+	Callee string
+	// CallValue SUN sent with the call
+	CallValue int64
+	// TokenValues TRC10 amounts sent with the call by token ID
+	TokenValues map[string]int64
+	// Note kind of call: call, create, suicide...
+	Note     string
+	Rejected bool
+}
+
+// InternalTransactions decodes the internal transactions of a receipt in
+// execution order, addresses as base58
+func InternalTransactions(info *core.TransactionInfo) []InternalTx {
+	internals := make([]InternalTx, 0, len(info.GetInternalTransactions()))
+	for _, itx := range info.GetInternalTransactions() {
+		decoded := InternalTx{
+			Hash:     common.BytesToHexString(itx.GetHash()),
+			Caller:   address.Address(itx.GetCallerAddress()).String(),
+			Callee:   address.Address(itx.GetTransferToAddress()).String(),
+			Note:     string(itx.GetNote()),
+			Rejected: itx.GetRejected(),
+		}
+		for _, value := range itx.GetCallValueInfo() {
+			if value.GetTokenId() == "" {
+				decoded.CallValue += value.GetCallValue()
+				continue
+			}
+			if decoded.TokenValues == nil {
+				decoded.TokenValues = make(map[string]int64)
+			}
+			decoded.TokenValues[value.GetTokenId()] += value.GetCallValue()
+		}
+		internals = append(internals, decoded)
+	}
+	return internals
+}
@@ -0,0 +1,52 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternalTransactions(t *testing.T) {
+	caller, err := common.DecodeCheck("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.Nil(t, err)
+	callee, err := common.DecodeCheck("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	require.Nil(t, err)
+
+	info := &core.TransactionInfo{InternalTransactions: []*core.InternalTransaction{
+		{
+			Hash:              []byte{0x01, 0x02},
+			CallerAddress:     caller,
+			TransferToAddress: callee,
+			CallValueInfo: []*core.InternalTransaction_CallValueInfo{
+				{CallValue: 1000000},
+				{CallValue: 5, TokenId: "1002000"},
+			},
+			Note: []byte("call"),
+		},
+		{
+			CallerAddress:     callee,
+			TransferToAddress: caller,
+			Note:              []byte("call"),
+			Rejected:          true,
+		},
+	}}
+
+	internals := InternalTransactions(info)
+	require.Len(t, internals, 2)
+	assert.Equal(t, InternalTx{
+		Hash:        "0x0102",
+		Caller:      "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Callee:      "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		CallValue:   1000000,
+		TokenValues: map[string]int64{"1002000": 5},
+		Note:        "call",
+	}, internals[0])
+	assert.Equal(t, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", internals[1].Callee)
+	assert.Zero(t, internals[1].CallValue)
+	assert.True(t, internals[1].Rejected)
+
+	assert.Empty(t, InternalTransactions(&core.TransactionInfo{}))
+}
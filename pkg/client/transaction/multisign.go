@@ -0,0 +1,78 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrDuplicateSigner is returned by Sign when two keys, or a key and an
+// existing signature, belong to the same account
+var ErrDuplicateSigner = errors.New("duplicate signer")
+
+// Sign appends a signature of each raw private key to the transaction, as
+// every owner of a multi signature permission would, without a keystore.
+// Nothing is signed when a key is invalid or its account already signed.
+// The signed Transaction is then broadcast with GrpcClient.Broadcast, or
+// handed to ExecuteTransaction with WithMultiSig when the controller account
+// adds the last signature.
+func (C *Controller) Sign(privKeys [][]byte) error {
+	if C.tx.GetRawData() == nil {
+		return ErrBadTransactionParam
+	}
+	rawData, err := proto.Marshal(C.tx.GetRawData())
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(rawData)
+
+	signers := make(map[string]bool)
+	for _, sig := range C.tx.GetSignature() {
+		signer, err := signatureSigner(hash[:], sig)
+		if err != nil {
+			return err
+		}
+		signers[signer] = true
+	}
+
+	signatures := make([][]byte, 0, len(privKeys))
+	for i, key := range privKeys {
+		sk, err := crypto.ToECDSA(key)
+		if err != nil {
+			return fmt.Errorf("key %d: %v", i, err)
+		}
+		signer := address.PubkeyToAddress(sk.PublicKey).String()
+		if signers[signer] {
+			return fmt.Errorf("%w: %s", ErrDuplicateSigner, signer)
+		}
+		signers[signer] = true
+		signature, err := crypto.Sign(hash[:], sk)
+		if err != nil {
+			return fmt.Errorf("key %d: %v", i, err)
+		}
+		signatures = append(signatures, signature)
+	}
+	C.tx.Signature = append(C.tx.Signature, signatures...)
+	return nil
+}
+
+// signatureSigner base58 address that produced sig over hash
+func signatureSigner(hash, sig []byte) (string, error) {
+	if len(sig) != 65 {
+		return "", fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	rsv := common.CopyBytes(sig)
+	if rsv[64] >= 27 {
+		rsv[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, rsv)
+	if err != nil {
+		return "", err
+	}
+	return address.PubkeyToAddress(*pub).String(), nil
+}
@@ -0,0 +1,32 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControllerSign(t *testing.T) {
+	tx, err := BuildTransfer("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		1000000, testRefBlock, time.UnixMilli(1700000060000))
+	require.Nil(t, err)
+	key1 := bytes.Repeat([]byte{0x01}, 32)
+	key2 := bytes.Repeat([]byte{0x02}, 32)
+
+	ctrlr := NewController(nil, nil, nil, tx)
+	require.Nil(t, ctrlr.Sign([][]byte{key1, key2}))
+	assert.Len(t, ctrlr.Transaction().Signature, 2)
+
+	err = ctrlr.Sign([][]byte{key2})
+	assert.True(t, errors.Is(err, ErrDuplicateSigner))
+	assert.Len(t, ctrlr.Transaction().Signature, 2)
+
+	tx.Signature = nil
+	err = ctrlr.Sign([][]byte{key1, key1})
+	assert.True(t, errors.Is(err, ErrDuplicateSigner))
+	assert.Empty(t, ctrlr.Transaction().Signature)
+}
@@ -0,0 +1,251 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/grpc/status"
+)
+
+// queueConfirmationWait is used when no confirmation wait time was set,
+// the queue only moves on once the previous transaction is confirmed
+const queueConfirmationWait = 60
+
+// queueExpiration validity of queued transactions from their reference block
+const queueExpiration = 60 * time.Second
+
+// Queue broadcasts transactions from one account one at a time, each one
+// anchored to a newer block than the previous and confirmed before the next
+type Queue struct {
+	client     *client.GrpcClient
+	ks         *keystore.KeyStore
+	account    *keystore.Account
+	passphrase string
+	options    []func(*Controller)
+
+	mu       sync.Mutex
+	pending  []func() (*core.Transaction, error)
+	lastRef  int64
+	Receipts []*core.TransactionInfo
+	budget   feeBudget
+	// inflight transaction of the first pending builder, broadcast but not
+	// confirmed yet
+	inflight *inflightTx
+}
+
+type inflightTx struct {
+	tx   *core.Transaction
+	txID string
+}
+
+// NewQueue initializes a Queue, options are applied to every transaction controller
+func NewQueue(
+	client *client.GrpcClient,
+	senderKs *keystore.KeyStore,
+	senderAcct *keystore.Account,
+	passphrase string,
+	options ...func(*Controller),
+) *Queue {
+	return &Queue{
+		client:     client,
+		ks:         senderKs,
+		account:    senderAcct,
+		passphrase: passphrase,
+		options:    options,
+	}
+}
+
+// Add appends a transaction builder, buildFn is called during Flush right
+// before the transaction is referenced, signed and broadcast
+func (q *Queue) Add(buildFn func() (*core.Transaction, error)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, buildFn)
+}
+
+// Len number of transactions waiting for Flush
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// SetFeeBudget stops Flush once fees paid, in SUN, go over limit
+func (q *Queue) SetFeeBudget(limit int64) {
+	q.budget.setLimit(limit)
+}
+
+// Spent total fees in SUN paid by the queued transactions so far
+func (q *Queue) Spent() int64 {
+	return q.budget.total()
+}
+
+// Flush sends queued transactions in order and stops at the first failure.
+// Transactions failing in their block are removed with their receipt stored,
+// the others stay queued. A transaction broadcast but not confirmed in time,
+// or whose broadcast got no answer, is polled again on the next Flush, it is
+// only built again once known to have expired without being included. Going
+// over the fee budget stops it with ErrFeeBudgetExceeded after the last
+// receipt is stored.
+func (q *Queue) Flush(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.mu.Unlock()
+			return nil
+		}
+		buildFn := q.pending[0]
+		q.mu.Unlock()
+
+		receipt, err := q.send(ctx, buildFn)
+		if receipt == nil {
+			return err
+		}
+		// in a block, failed or not, it is never sent again. Failed
+		// contract calls still pay for the energy they used.
+		q.mu.Lock()
+		q.pending = q.pending[1:]
+		q.Receipts = append(q.Receipts, receipt)
+		q.mu.Unlock()
+		exceeded := q.budget.add(receipt)
+		if err != nil {
+			return err
+		}
+		if exceeded {
+			return fmt.Errorf("%w: spent %d SUN", ErrFeeBudgetExceeded, q.Spent())
+		}
+	}
+}
+
+// send returns the receipt of the transaction of buildFn, building and
+// broadcasting it unless an earlier one is still in flight
+func (q *Queue) send(ctx context.Context, buildFn func() (*core.Transaction, error)) (*core.TransactionInfo, error) {
+	if q.inflight != nil {
+		receipt, err := q.poll(ctx)
+		if receipt != nil || err != nil {
+			return receipt, err
+		}
+	}
+
+	tx, err := buildFn()
+	if err != nil {
+		return nil, err
+	}
+	if tx.GetRawData() == nil {
+		return nil, ErrBadTransactionParam
+	}
+	ref, timestamp, err := q.nextRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = setReference(tx, ref, timestamp.Add(queueExpiration)); err != nil {
+		return nil, err
+	}
+	tx.Signature = nil
+
+	options := append([]func(*Controller){}, q.options...)
+	options = append(options, WithPassphrase(q.passphrase))
+	ctrlr := NewController(q.client, q.ks, q.account, tx, options...)
+	if ctrlr.Behavior.ConfirmationWaitTime == 0 {
+		ctrlr.Behavior.ConfirmationWaitTime = queueConfirmationWait
+	}
+	err = ctrlr.ExecuteTransaction()
+	if ctrlr.Result == nil && !broadcastUnanswered(ctrlr.Transaction(), err) {
+		// rejected before or by the broadcast, it can be built again
+		return nil, err
+	}
+	if err != nil {
+		// not confirmed in time, or the broadcast reply was lost and the
+		// node may have taken it: polled before anything is built again
+		txID, hashErr := ctrlr.TransactionHash()
+		if hashErr != nil {
+			return nil, hashErr
+		}
+		q.inflight = &inflightTx{tx: ctrlr.Transaction(), txID: txID}
+		return nil, err
+	}
+	return ctrlr.Receipt, ctrlr.GetResultError()
+}
+
+// broadcastUnanswered reports whether err, from executing the signed tx, is
+// a transport error rather than a node answer, so tx may have been sent.
+// Node rejections are not gRPC status errors.
+func broadcastUnanswered(tx *core.Transaction, err error) bool {
+	if err == nil || len(tx.GetSignature()) == 0 {
+		return false
+	}
+	_, ok := status.FromError(err)
+	return ok
+}
+
+// poll waits up to the confirmation wait for the receipt of the in flight
+// transaction. Nothing is returned when it expired without being included,
+// the builder is then called again.
+func (q *Queue) poll(ctx context.Context) (*core.TransactionInfo, error) {
+	deadline := time.Now().Add(queueConfirmationWait * time.Second)
+	expiration := time.UnixMilli(q.inflight.tx.GetRawData().GetExpiration())
+	for {
+		info, err := q.client.GetTransactionInfoByID(q.inflight.txID)
+		if err != nil && !errors.Is(err, client.ErrTransactionInfoNotFound) {
+			return nil, err
+		}
+		if err == nil && info.GetBlockNumber() > 0 {
+			q.inflight = nil
+			if info.GetResult() == core.TransactionInfo_FAILED {
+				return info, fmt.Errorf("%s", info.GetResMessage())
+			}
+			return info, nil
+		}
+		if time.Now().After(expiration.Add(txQueueExpiryMargin)) {
+			included, err := transactionIncluded(q.client, q.inflight.tx, q.inflight.txID)
+			if err != nil && err != errNotSettled {
+				return nil, err
+			}
+			if err == nil && !included {
+				q.inflight = nil
+				return nil, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("could not confirm transaction %s after %d seconds", q.inflight.txID, queueConfirmationWait)
+		}
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// nextRef waits for a block newer than the last one used as reference
+func (q *Queue) nextRef(ctx context.Context) (RefBlock, time.Time, error) {
+	for {
+		block, err := q.client.GetNowBlock()
+		if err != nil {
+			return RefBlock{}, time.Time{}, err
+		}
+		header := block.GetBlockHeader().GetRawData()
+		if header == nil || len(block.GetBlockid()) == 0 {
+			return RefBlock{}, time.Time{}, fmt.Errorf("invalid block")
+		}
+		if header.Number > q.lastRef {
+			q.lastRef = header.Number
+			return RefBlock{Number: header.Number, Hash: block.GetBlockid()},
+				time.UnixMilli(header.Timestamp), nil
+		}
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return RefBlock{}, time.Time{}, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQueueInflight(t *testing.T) {
+	expiration := int64(1700000060000)
+	ref := testRefBlock.Number
+	tx, err := BuildTransfer("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		1000000, testRefBlock, time.UnixMilli(expiration))
+	require.Nil(t, err)
+	txID, err := transactionID(tx)
+	require.Nil(t, err)
+	id, err := common.FromHex(txID)
+	require.Nil(t, err)
+
+	wallet := &fakeWallet{head: testBlock(ref+3, expiration+3000)}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+	queue := NewQueue(c, nil, nil, "")
+
+	builds := 0
+	errBuild := errors.New("built again")
+	queue.Add(func() (*core.Transaction, error) {
+		builds++
+		return nil, errBuild
+	})
+
+	// the broadcast transaction made it, its receipt is used
+	queue.inflight = &inflightTx{tx: tx, txID: txID}
+	wallet.info = &core.TransactionInfo{Id: id, BlockNumber: ref + 2}
+	require.Nil(t, queue.Flush(context.Background()))
+	assert.Equal(t, 0, builds)
+	assert.Equal(t, 0, queue.Len())
+	require.Len(t, queue.Receipts, 1)
+	assert.Nil(t, queue.inflight)
+
+	// expired and not in any block up to expiration, built again
+	queue.Add(func() (*core.Transaction, error) {
+		builds++
+		return nil, errBuild
+	})
+	queue.inflight = &inflightTx{tx: tx, txID: txID}
+	wallet.info = nil
+	wallet.blocks = []*api.BlockExtention{
+		testBlock(ref+1, expiration-3000),
+		testBlock(ref+2, expiration),
+		testBlock(ref+3, expiration+3000),
+	}
+	assert.Equal(t, errBuild, queue.Flush(context.Background()))
+	assert.Equal(t, 1, builds)
+	assert.Equal(t, 1, queue.Len())
+	assert.Nil(t, queue.inflight)
+	assert.Equal(t, 0, wallet.broadcasts)
+}
+
+func TestQueueBroadcastUnanswered(t *testing.T) {
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	key, err := crypto.HexToECDSA("b5a4cea271ff424d7c31dc12a3e43e401df7a40d7412a15750f3f0b6b5449a28")
+	require.Nil(t, err)
+	account, err := ks.ImportECDSA(key, "secret")
+	require.Nil(t, err)
+
+	head := testBlock(testRefBlock.Number+3, time.Now().UnixMilli())
+	head.Blockid = make([]byte, 32)
+	wallet := &fakeWallet{head: head, broadcastErr: status.Error(codes.DeadlineExceeded, "reply lost")}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+	queue := NewQueue(c, ks, &account, "secret")
+
+	builds := 0
+	queue.Add(func() (*core.Transaction, error) {
+		builds++
+		return BuildTransfer(account.Address.String(), "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+			1000000, testRefBlock, time.Now().Add(time.Minute))
+	})
+
+	// the node may have taken it, it is not built again
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(queue.Flush(context.Background())))
+	require.NotNil(t, queue.inflight)
+	assert.Equal(t, 1, builds)
+	assert.Equal(t, 1, wallet.broadcasts)
+
+	id, err := common.FromHex(queue.inflight.txID)
+	require.Nil(t, err)
+	wallet.info = &core.TransactionInfo{Id: id, BlockNumber: head.GetBlockHeader().GetRawData().GetNumber()}
+	require.Nil(t, queue.Flush(context.Background()))
+	assert.Equal(t, 1, builds)
+	assert.Equal(t, 1, wallet.broadcasts)
+	require.Len(t, queue.Receipts, 1)
+	assert.Equal(t, 0, queue.Len())
+}
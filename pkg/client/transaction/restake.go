@@ -0,0 +1,98 @@
+package transaction
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// restakeConfirmationTime seconds waited for the cancel receipt when the
+// options do not set a confirmation time
+const restakeConfirmationTime = 60
+
+// RestakeResult transactions sent by CancelUnfreezeAndDelegate
+type RestakeResult struct {
+	CancelTxID   string
+	DelegateTxID string
+	// Amount SUN delegated to the receiver
+	Amount int64
+}
+
+// CancelUnfreezeAndDelegate cancels every pending unfreeze of the signer,
+// waits for the cancel to be confirmed and then delegates amount SUN of
+// resource to receiver. An amount of 0 delegates what was pending for
+// resource. Both transactions are signed by the same account, keystore
+// signers need WithPassphrase. When the delegation fails the cancel stays
+// confirmed and its ID is returned along with the error.
+func CancelUnfreezeAndDelegate(
+	c *client.GrpcClient,
+	ks *keystore.KeyStore,
+	acct *keystore.Account,
+	receiver string,
+	resource core.ResourceCode,
+	amount int64,
+	options ...func(*Controller),
+) (*RestakeResult, error) {
+	owner := acct.Address.String()
+	acc, err := c.GetAccount(owner)
+	if err != nil {
+		return nil, err
+	}
+	pending := pendingUnfreeze(acc, resource, time.Now())
+	if amount == 0 {
+		amount = pending
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("no pending %s unfreeze to delegate", resource.String())
+	}
+
+	cancelTx, err := c.CancelAllUnfreezeV2(owner)
+	if err != nil {
+		return nil, err
+	}
+	// the delegation needs the stake back, always wait for the cancel
+	cancelCtrlr := NewController(c, ks, acct, cancelTx.Transaction, options...)
+	if cancelCtrlr.Behavior.DryRun {
+		return nil, fmt.Errorf("dry run can not wait for the cancel to delegate")
+	}
+	if cancelCtrlr.Behavior.ConfirmationWaitTime == 0 {
+		cancelCtrlr.Behavior.ConfirmationWaitTime = restakeConfirmationTime
+	}
+	if err = cancelCtrlr.ExecuteTransaction(); err != nil {
+		return nil, err
+	}
+	result := &RestakeResult{Amount: amount}
+	result.CancelTxID, _ = cancelCtrlr.TransactionHash()
+	if err = cancelCtrlr.GetResultError(); err != nil {
+		return result, fmt.Errorf("cancel unfreeze %s failed: %v", result.CancelTxID, err)
+	}
+
+	delegateTx, err := c.DelegateResource(owner, receiver, resource, amount, false, 0)
+	if err != nil {
+		return result, fmt.Errorf("cancel unfreeze %s confirmed, delegation not sent: %v", result.CancelTxID, err)
+	}
+	delegateCtrlr := NewController(c, ks, acct, delegateTx.Transaction, options...)
+	if err = delegateCtrlr.ExecuteTransaction(); err != nil {
+		return result, fmt.Errorf("cancel unfreeze %s confirmed, delegation failed: %v", result.CancelTxID, err)
+	}
+	result.DelegateTxID, _ = delegateCtrlr.TransactionHash()
+	if err = delegateCtrlr.GetResultError(); err != nil {
+		return result, fmt.Errorf("delegation %s failed: %v", result.DelegateTxID, err)
+	}
+	return result, nil
+}
+
+// pendingUnfreeze SUN of resource still in the unfreeze waiting period at
+// now, what a cancel returns to stake
+func pendingUnfreeze(acc *core.Account, resource core.ResourceCode, now time.Time) int64 {
+	var pending int64
+	for _, unfreeze := range acc.GetUnfrozenV2() {
+		if unfreeze.GetType() == resource && unfreeze.GetUnfreezeExpireTime() > now.UnixMilli() {
+			pending += unfreeze.GetUnfreezeAmount()
+		}
+	}
+	return pending
+}
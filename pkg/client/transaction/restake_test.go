@@ -0,0 +1,21 @@
+package transaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingUnfreeze(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	acc := &core.Account{UnfrozenV2: []*core.Account_UnFreezeV2{
+		{Type: core.ResourceCode_ENERGY, UnfreezeAmount: 3000000, UnfreezeExpireTime: 1700000100000},
+		{Type: core.ResourceCode_ENERGY, UnfreezeAmount: 2000000, UnfreezeExpireTime: 1699999900000},
+		{Type: core.ResourceCode_BANDWIDTH, UnfreezeAmount: 1000000, UnfreezeExpireTime: 1700000100000},
+	}}
+	assert.Equal(t, int64(3000000), pendingUnfreeze(acc, core.ResourceCode_ENERGY, now))
+	assert.Equal(t, int64(1000000), pendingUnfreeze(acc, core.ResourceCode_BANDWIDTH, now))
+	assert.Equal(t, int64(0), pendingUnfreeze(&core.Account{}, core.ResourceCode_ENERGY, now))
+}
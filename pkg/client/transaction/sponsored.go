@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+)
+
+// ErrInsufficientSponsorEnergy is returned when the energy delegated by the
+// sponsor, or the sender available energy, does not cover the call
+var ErrInsufficientSponsorEnergy = errors.New("sponsored energy does not cover the call")
+
+// SponsoredCall triggers a contract method signed by the sender account after
+// checking that energy delegated by sponsor to the sender covers the call, so
+// the sender burns no TRX for energy. Delegation itself is done beforehand by
+// the sponsor with DelegateResource. The executed controller is returned.
+func SponsoredCall(
+	c *client.GrpcClient,
+	sponsor string,
+	senderKs *keystore.KeyStore,
+	senderAcct *keystore.Account,
+	contractAddress, method, jsonParams string,
+	feeLimit int64,
+	options ...func(*Controller),
+) (*Controller, error) {
+	sender := senderAcct.Address.String()
+
+	estimate, err := c.TriggerConstantContract(sender, contractAddress, method, jsonParams)
+	if err != nil {
+		return nil, err
+	}
+	required := estimate.GetEnergyUsed()
+
+	delegated, err := c.GetDelegatedResourceV2(sponsor, sender)
+	if err != nil {
+		return nil, err
+	}
+	var frozen int64
+	for _, resource := range delegated.GetDelegatedResource() {
+		frozen += resource.GetFrozenBalanceForEnergy()
+	}
+	resources, err := c.GetAccountResourceDetailed(sender)
+	if err != nil {
+		return nil, err
+	}
+	sponsored := resources.EnergyForStake(frozen)
+	if sponsored < required {
+		return nil, fmt.Errorf("%w: %s delegated %d energy, call needs %d",
+			ErrInsufficientSponsorEnergy, sponsor, sponsored, required)
+	}
+	if available := resources.AvailableEnergy(); available < required {
+		return nil, fmt.Errorf("%w: %s has %d energy left, call needs %d",
+			ErrInsufficientSponsorEnergy, sender, available, required)
+	}
+
+	tx, err := c.TriggerContract(sender, contractAddress, method, jsonParams, feeLimit, 0, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	ctrlr := NewController(c, senderKs, senderAcct, tx.Transaction, options...)
+	if err = ctrlr.ExecuteTransaction(); err != nil {
+		return nil, err
+	}
+	return ctrlr, nil
+}
@@ -0,0 +1,458 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// TxStatus stage of a transaction in a TxQueue
+type TxStatus int
+
+const (
+	// TxPending waits to be signed and broadcast
+	TxPending TxStatus = iota
+	// TxSent was signed and handed to the node, waiting for its receipt
+	TxSent
+	// TxConfirmed is in a block and succeeded
+	TxConfirmed
+	// TxFailed expired, ran out of attempts or failed in its block
+	TxFailed
+)
+
+// String name of the status
+func (s TxStatus) String() string {
+	switch s {
+	case TxPending:
+		return "pending"
+	case TxSent:
+		return "sent"
+	case TxConfirmed:
+		return "confirmed"
+	case TxFailed:
+		return "failed"
+	}
+	return fmt.Sprintf("status(%d)", int(s))
+}
+
+// QueuedTx a transaction tracked by a TxQueue. ID is assigned on Enqueue and
+// never changes, TxID does when an expired unsigned transaction is refreshed.
+type QueuedTx struct {
+	ID          string
+	Tx          *core.Transaction
+	Presigned   bool
+	Status      TxStatus
+	TxID        string
+	Attempts    int
+	LastError   string
+	BlockNumber int64
+	CreatedAt   time.Time
+}
+
+// TxStore persists queued transactions, implemented by callers over their
+// database so a TxQueue resumes after a restart
+type TxStore interface {
+	// Put inserts the entry or replaces the one with the same ID
+	Put(entry *QueuedTx) error
+	// Unfinished returns entries not confirmed nor failed, oldest first
+	Unfinished() ([]*QueuedTx, error)
+}
+
+// MemoryTxStore TxStore kept in memory, entries are lost on exit
+type MemoryTxStore struct {
+	mu      sync.Mutex
+	entries map[string]*QueuedTx
+	order   []string
+}
+
+// NewMemoryTxStore initializes an empty MemoryTxStore
+func NewMemoryTxStore() *MemoryTxStore {
+	return &MemoryTxStore{entries: make(map[string]*QueuedTx)}
+}
+
+// Put stores a copy of entry
+func (m *MemoryTxStore) Put(entry *QueuedTx) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entries[entry.ID]; !ok {
+		m.order = append(m.order, entry.ID)
+	}
+	stored := *entry
+	stored.Tx = proto.Clone(entry.Tx).(*core.Transaction)
+	m.entries[entry.ID] = &stored
+	return nil
+}
+
+// Unfinished returns copies of entries not confirmed nor failed
+func (m *MemoryTxStore) Unfinished() ([]*QueuedTx, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]*QueuedTx, 0)
+	for _, id := range m.order {
+		entry := m.entries[id]
+		if entry.Status == TxConfirmed || entry.Status == TxFailed {
+			continue
+		}
+		stored := *entry
+		stored.Tx = proto.Clone(entry.Tx).(*core.Transaction)
+		list = append(list, &stored)
+	}
+	return list, nil
+}
+
+// Get returns a copy of the entry with the given ID
+func (m *MemoryTxStore) Get(id string) (*QueuedTx, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[id]
+	if !ok {
+		return nil, false
+	}
+	stored := *entry
+	return &stored, true
+}
+
+// txQueueMaxAttempts broadcast attempts before an entry is marked failed
+const txQueueMaxAttempts = 5
+
+// txQueuePollInterval wait between passes over unfinished entries
+const txQueuePollInterval = 3 * time.Second
+
+// txQueueExpiryMargin wait past expiration before a missing transaction is
+// considered dropped
+const txQueueExpiryMargin = 10 * time.Second
+
+// TxQueue durable pipeline signing, broadcasting and confirming transactions
+// of one account. Every state change is written to the store before acting on
+// it, so a restarted worker rebroadcasts sent transactions instead of signing
+// new ones, duplicates are ignored by the node.
+type TxQueue struct {
+	client     *client.GrpcClient
+	ks         *keystore.KeyStore
+	account    *keystore.Account
+	passphrase string
+	options    []func(*Controller)
+	store      TxStore
+
+	// MaxAttempts times an entry is built or expires unconfirmed before it
+	// is marked failed, broadcast errors are not counted
+	MaxAttempts  int
+	PollInterval time.Duration
+}
+
+// NewTxQueue initializes a TxQueue over store, options are applied to every
+// transaction controller
+func NewTxQueue(
+	client *client.GrpcClient,
+	senderKs *keystore.KeyStore,
+	senderAcct *keystore.Account,
+	passphrase string,
+	store TxStore,
+	options ...func(*Controller),
+) *TxQueue {
+	return &TxQueue{
+		client:       client,
+		ks:           senderKs,
+		account:      senderAcct,
+		passphrase:   passphrase,
+		options:      options,
+		store:        store,
+		MaxAttempts:  txQueueMaxAttempts,
+		PollInterval: txQueuePollInterval,
+	}
+}
+
+// Enqueue stores tx as pending and returns its queue ID. Signed transactions
+// are broadcast as they are, unsigned ones are signed by the queue account
+// and get a new reference block if they expire before being sent.
+func (q *TxQueue) Enqueue(tx *core.Transaction) (string, error) {
+	if tx.GetRawData() == nil {
+		return "", ErrBadTransactionParam
+	}
+	txID, err := transactionID(tx)
+	if err != nil {
+		return "", err
+	}
+	entry := &QueuedTx{
+		ID:        txID,
+		Tx:        tx,
+		Presigned: len(tx.GetSignature()) > 0,
+		Status:    TxPending,
+		TxID:      txID,
+		CreatedAt: time.Now(),
+	}
+	if err = q.store.Put(entry); err != nil {
+		return "", err
+	}
+	return entry.ID, nil
+}
+
+// Run drives unfinished entries to confirmation until ctx is done, it only
+// returns early on store errors
+func (q *TxQueue) Run(ctx context.Context) error {
+	for {
+		if err := q.ProcessOnce(); err != nil {
+			return err
+		}
+		select {
+		case <-time.After(q.PollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ProcessOnce moves every unfinished entry forward by one step
+func (q *TxQueue) ProcessOnce() error {
+	entries, err := q.store.Unfinished()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		switch entry.Status {
+		case TxPending:
+			err = q.send(entry)
+		case TxSent:
+			err = q.check(entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send signs the entry, stores it as sent and broadcasts it
+func (q *TxQueue) send(entry *QueuedTx) error {
+	expired := time.Now().UnixMilli() > entry.Tx.GetRawData().GetExpiration()
+	if expired && entry.Presigned {
+		return q.fail(entry, "expired before broadcast")
+	}
+	if expired {
+		if err := q.refresh(entry); err != nil {
+			return q.retry(entry, err)
+		}
+	}
+	if !entry.Presigned {
+		options := append([]func(*Controller){}, q.options...)
+		options = append(options, WithPassphrase(q.passphrase), func(C *Controller) {
+			C.Behavior.DryRun = true
+		})
+		ctrlr := NewController(q.client, q.ks, q.account, entry.Tx, options...)
+		if err := ctrlr.ExecuteTransaction(); err != nil {
+			return q.fail(entry, err.Error())
+		}
+		entry.Tx = ctrlr.Transaction()
+	}
+	txID, err := transactionID(entry.Tx)
+	if err != nil {
+		return q.fail(entry, err.Error())
+	}
+	entry.TxID = txID
+	entry.Status = TxSent
+	if err = q.store.Put(entry); err != nil {
+		return err
+	}
+	return q.broadcast(entry)
+}
+
+// check looks for the receipt of a sent entry, rebroadcasting it while it
+// can still be included. Lookup errors leave the entry sent, only a
+// transaction known to have missed its expiration is signed again.
+func (q *TxQueue) check(entry *QueuedTx) error {
+	info, err := q.client.GetTransactionInfoByID(entry.TxID)
+	if err != nil && !errors.Is(err, client.ErrTransactionInfoNotFound) {
+		entry.LastError = err.Error()
+		return q.store.Put(entry)
+	}
+	if err == nil && info.GetBlockNumber() > 0 {
+		entry.BlockNumber = info.GetBlockNumber()
+		if info.GetResult() == core.TransactionInfo_FAILED {
+			return q.fail(entry, string(info.GetResMessage()))
+		}
+		entry.Status = TxConfirmed
+		entry.LastError = ""
+		return q.store.Put(entry)
+	}
+
+	expiration := time.UnixMilli(entry.Tx.GetRawData().GetExpiration())
+	if time.Now().Before(expiration) {
+		return q.broadcast(entry)
+	}
+	if time.Now().Before(expiration.Add(txQueueExpiryMargin)) {
+		return nil
+	}
+	if entry.Presigned {
+		return q.fail(entry, "expired without confirmation")
+	}
+	included, err := transactionIncluded(q.client, entry.Tx, entry.TxID)
+	if err != nil {
+		entry.LastError = err.Error()
+		return q.store.Put(entry)
+	}
+	if included {
+		// the receipt shows up on a later pass
+		return nil
+	}
+	// never included, sign it again with a new reference block
+	entry.Tx.Signature = nil
+	entry.Status = TxPending
+	return q.retry(entry, errors.New("expired without confirmation"))
+}
+
+// errNotSettled the chain has not moved past the entry expiration yet
+var errNotSettled = errors.New("waiting for blocks past expiration")
+
+// transactionIncluded tells whether tx, with ID txID and past its
+// expiration, made it into a block. The solidity node answers when
+// connected, otherwise the blocks from the reference block up to the
+// expiration are scanned for it.
+func transactionIncluded(c *client.GrpcClient, tx *core.Transaction, txID string) (bool, error) {
+	expiration := tx.GetRawData().GetExpiration()
+	if c.Solidity != nil {
+		_, err := c.GetSolidTransactionInfoByID(txID)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, client.ErrTransactionInfoNotFound) {
+			return false, err
+		}
+		solid, err := c.GetNowBlockSolidity()
+		if err != nil {
+			return false, err
+		}
+		if solid.GetBlockHeader().GetRawData().GetTimestamp() <= expiration {
+			return false, errNotSettled
+		}
+		return false, nil
+	}
+
+	id, err := common.FromHex(txID)
+	if err != nil {
+		return false, err
+	}
+	head, err := c.GetNowBlock()
+	if err != nil {
+		return false, err
+	}
+	header := head.GetBlockHeader().GetRawData()
+	if header.GetTimestamp() <= expiration {
+		return false, errNotSettled
+	}
+	if len(tx.GetRawData().GetRefBlockBytes()) != 2 {
+		return false, fmt.Errorf("can not scan for transaction without reference block")
+	}
+	next := refBlockNumber(header.GetNumber(), tx.GetRawData().GetRefBlockBytes()) + 1
+	for next <= header.GetNumber() {
+		end := next + txQueueScanBatch
+		if end > header.GetNumber()+1 {
+			end = header.GetNumber() + 1
+		}
+		list, err := c.GetBlockByLimitNext(next, end)
+		if err != nil {
+			return false, err
+		}
+		if len(list.GetBlock()) == 0 {
+			return false, fmt.Errorf("no blocks returned from %d", next)
+		}
+		for _, block := range list.GetBlock() {
+			if block.GetBlockHeader().GetRawData().GetTimestamp() > expiration {
+				return false, nil
+			}
+			if blockHasTransaction(block, id) {
+				return true, nil
+			}
+		}
+		next += int64(len(list.GetBlock()))
+	}
+	return false, nil
+}
+
+// txQueueScanBatch blocks asked at once while scanning, the node limit
+const txQueueScanBatch = 100
+
+// refBlockNumber highest block number up to head ending with refBlockBytes,
+// the last two bytes of the reference block number
+func refBlockNumber(head int64, refBlockBytes []byte) int64 {
+	low := int64(binary.BigEndian.Uint16(refBlockBytes))
+	number := head&^0xffff | low
+	if number > head {
+		number -= 0x10000
+	}
+	return number
+}
+
+func blockHasTransaction(block *api.BlockExtention, txID []byte) bool {
+	for _, tx := range block.GetTransactions() {
+		if bytes.Equal(tx.GetTxid(), txID) {
+			return true
+		}
+	}
+	return false
+}
+
+// broadcast hands a sent entry to the node. Errors only leave a note: the
+// node may have taken it anyway, so the entry stays sent until its receipt
+// or expiration decides the outcome.
+func (q *TxQueue) broadcast(entry *QueuedTx) error {
+	result, err := q.client.Broadcast(entry.Tx)
+	if err != nil && result.GetCode() != api.Return_DUP_TRANSACTION_ERROR {
+		entry.LastError = err.Error()
+		return q.store.Put(entry)
+	}
+	return nil
+}
+
+// refresh points an unsigned transaction to the latest block
+func (q *TxQueue) refresh(entry *QueuedTx) error {
+	block, err := q.client.GetNowBlock()
+	if err != nil {
+		return err
+	}
+	header := block.GetBlockHeader().GetRawData()
+	if header == nil || len(block.GetBlockid()) == 0 {
+		return fmt.Errorf("invalid block")
+	}
+	entry.Tx.Signature = nil
+	return setReference(entry.Tx, RefBlock{Number: header.Number, Hash: block.GetBlockid()},
+		time.UnixMilli(header.Timestamp).Add(queueExpiration))
+}
+
+// retry records a failed attempt of an entry known not to be on chain, it
+// fails once attempts run out
+func (q *TxQueue) retry(entry *QueuedTx, cause error) error {
+	entry.Attempts++
+	entry.LastError = cause.Error()
+	if entry.Attempts >= q.MaxAttempts {
+		entry.Status = TxFailed
+	}
+	return q.store.Put(entry)
+}
+
+func (q *TxQueue) fail(entry *QueuedTx, reason string) error {
+	entry.Status = TxFailed
+	entry.LastError = reason
+	return q.store.Put(entry)
+}
+
+// transactionID hash of the raw data as HEX
+func transactionID(tx *core.Transaction) (string, error) {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(rawData)
+	return common.BytesToHexString(hash[:]), nil
+}
@@ -0,0 +1,204 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTxQueueStore(t *testing.T) {
+	store := NewMemoryTxStore()
+	queue := NewTxQueue(nil, nil, nil, "", store)
+
+	// expired long ago, a presigned transaction can not be refreshed
+	tx, err := BuildTransfer("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		1000000, testRefBlock, time.UnixMilli(1700000060000))
+	require.Nil(t, err)
+	tx.Signature = [][]byte{make([]byte, 65)}
+
+	id, err := queue.Enqueue(tx)
+	require.Nil(t, err)
+	pending, err := store.Unfinished()
+	require.Nil(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, TxPending, pending[0].Status)
+	assert.True(t, pending[0].Presigned)
+	assert.Equal(t, id, pending[0].TxID)
+
+	// the store keeps its own copy
+	tx.RawData.Expiration = 0
+	pending, _ = store.Unfinished()
+	assert.Equal(t, int64(1700000060000), pending[0].Tx.GetRawData().GetExpiration())
+
+	require.Nil(t, queue.ProcessOnce())
+	pending, err = store.Unfinished()
+	require.Nil(t, err)
+	assert.Empty(t, pending)
+	entry, ok := store.Get(id)
+	require.True(t, ok)
+	assert.Equal(t, TxFailed, entry.Status)
+	assert.Equal(t, "expired before broadcast", entry.LastError)
+}
+
+// fakeWallet node answering the calls a TxQueue makes, others panic
+type fakeWallet struct {
+	api.WalletClient
+	info         *core.TransactionInfo
+	infoErr      error
+	head         *api.BlockExtention
+	blocks       []*api.BlockExtention
+	broadcasts   int
+	broadcastErr error
+}
+
+func (f *fakeWallet) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error) {
+	if f.info != nil {
+		return f.info, f.infoErr
+	}
+	return &core.TransactionInfo{}, f.infoErr
+}
+
+func (f *fakeWallet) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return f.head, nil
+}
+
+func (f *fakeWallet) GetBlockByLimitNext2(ctx context.Context, in *api.BlockLimit, opts ...grpc.CallOption) (*api.BlockListExtention, error) {
+	list := &api.BlockListExtention{}
+	for _, b := range f.blocks {
+		if n := b.GetBlockHeader().GetRawData().GetNumber(); n >= in.StartNum && n < in.EndNum {
+			list.Block = append(list.Block, b)
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeWallet) BroadcastTransaction(ctx context.Context, in *core.Transaction, opts ...grpc.CallOption) (*api.Return, error) {
+	f.broadcasts++
+	if f.broadcastErr != nil {
+		return nil, f.broadcastErr
+	}
+	return &api.Return{Result: true}, nil
+}
+
+func testBlock(number, timestamp int64, txIDs ...[]byte) *api.BlockExtention {
+	block := &api.BlockExtention{BlockHeader: &core.BlockHeader{
+		RawData: &core.BlockHeaderRaw{Number: number, Timestamp: timestamp},
+	}}
+	for _, id := range txIDs {
+		block.Transactions = append(block.Transactions, &api.TransactionExtention{Txid: id})
+	}
+	return block
+}
+
+// sentEntry stores an unsigned transfer, expired long ago, as sent
+func sentEntry(t *testing.T, store *MemoryTxStore, queue *TxQueue) *QueuedTx {
+	tx, err := BuildTransfer("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		1000000, testRefBlock, time.UnixMilli(1700000060000))
+	require.Nil(t, err)
+	id, err := queue.Enqueue(tx)
+	require.Nil(t, err)
+	entry, ok := store.Get(id)
+	require.True(t, ok)
+	entry.Status = TxSent
+	require.Nil(t, store.Put(entry))
+	return entry
+}
+
+func TestTxQueueCheckRPCError(t *testing.T) {
+	wallet := &fakeWallet{infoErr: status.Error(codes.Unavailable, "node down")}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+	store := NewMemoryTxStore()
+	queue := NewTxQueue(c, nil, nil, "", store)
+	entry := sentEntry(t, store, queue)
+
+	require.Nil(t, queue.ProcessOnce())
+	stored, ok := store.Get(entry.ID)
+	require.True(t, ok)
+	assert.Equal(t, TxSent, stored.Status)
+	assert.Contains(t, stored.LastError, "node down")
+	assert.Equal(t, 0, wallet.broadcasts)
+}
+
+func TestTxQueueCheckRebuild(t *testing.T) {
+	expiration := int64(1700000060000)
+	ref := testRefBlock.Number
+	wallet := &fakeWallet{head: testBlock(ref+3, expiration+3000)}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+	store := NewMemoryTxStore()
+	queue := NewTxQueue(c, nil, nil, "", store)
+	entry := sentEntry(t, store, queue)
+	txID, err := common.FromHex(entry.TxID)
+	require.Nil(t, err)
+
+	// included in a block before expiration, only the receipt is missing
+	wallet.blocks = []*api.BlockExtention{
+		testBlock(ref+1, expiration-3000),
+		testBlock(ref+2, expiration, txID),
+		testBlock(ref+3, expiration+3000),
+	}
+	require.Nil(t, queue.ProcessOnce())
+	stored, _ := store.Get(entry.ID)
+	assert.Equal(t, TxSent, stored.Status)
+
+	// only in a block past expiration, which the node would never accept
+	wallet.blocks = []*api.BlockExtention{
+		testBlock(ref+1, expiration-3000),
+		testBlock(ref+2, expiration),
+		testBlock(ref+3, expiration+3000, txID),
+	}
+	require.Nil(t, queue.ProcessOnce())
+	stored, _ = store.Get(entry.ID)
+	assert.Equal(t, TxPending, stored.Status)
+	assert.Equal(t, "expired without confirmation", stored.LastError)
+	assert.Equal(t, 1, stored.Attempts)
+	assert.Equal(t, 0, wallet.broadcasts)
+
+	// the head has not passed expiration, nothing is decided
+	wallet.head = testBlock(ref+2, expiration)
+	stored.Status = TxSent
+	require.Nil(t, store.Put(stored))
+	require.Nil(t, queue.ProcessOnce())
+	stored, _ = store.Get(entry.ID)
+	assert.Equal(t, TxSent, stored.Status)
+	assert.Equal(t, errNotSettled.Error(), stored.LastError)
+}
+
+func TestTxQueueCheckBroadcastError(t *testing.T) {
+	wallet := &fakeWallet{broadcastErr: status.Error(codes.Unavailable, "reply lost")}
+	c := client.NewGrpcClient("")
+	c.Client = wallet
+	store := NewMemoryTxStore()
+	queue := NewTxQueue(c, nil, nil, "", store)
+	entry := sentEntry(t, store, queue)
+	entry.Tx.RawData.Expiration = time.Now().Add(time.Minute).UnixMilli()
+	require.Nil(t, store.Put(entry))
+
+	// the node may have the transaction, it can not be given up on
+	for i := 0; i < 2*queue.MaxAttempts; i++ {
+		require.Nil(t, queue.ProcessOnce())
+	}
+	stored, ok := store.Get(entry.ID)
+	require.True(t, ok)
+	assert.Equal(t, TxSent, stored.Status)
+	assert.Equal(t, 0, stored.Attempts)
+	assert.Contains(t, stored.LastError, "reply lost")
+	assert.Equal(t, 2*queue.MaxAttempts, wallet.broadcasts)
+}
+
+func TestRefBlockNumber(t *testing.T) {
+	assert.Equal(t, int64(0x2a3b4c5d), refBlockNumber(0x2a3b4c60, []byte{0x4c, 0x5d}))
+	assert.Equal(t, int64(0x2a3afff0), refBlockNumber(0x2a3b0010, []byte{0xff, 0xf0}))
+	assert.Equal(t, int64(0x2a3b0010), refBlockNumber(0x2a3b0010, []byte{0x00, 0x10}))
+}
@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrOwnerNotSigned is returned by VerifySignatures when a contract using the
+// owner permission lacks the signature of its owner
+var ErrOwnerNotSigned = errors.New("contract owner did not sign")
+
+// RawDataHash sha256 of the serialized raw data, the transaction ID and the
+// digest every signature is made over. Nil when raw data can not be encoded.
+func RawDataHash(tx *core.Transaction) []byte {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil
+	}
+	hash := sha256.Sum256(rawData)
+	return hash[:]
+}
+
+// VerifySignatures recovers the account behind every signature, in order,
+// without a node. It fails on malformed signatures, on two signatures of the
+// same account and when the owner of a contract under the owner permission
+// (ID 0) did not sign. Signatures under other permissions are only returned,
+// their weight is checked by nodes against the account permissions, see
+// GrpcClient.GetTransactionSignWeight.
+func VerifySignatures(tx *core.Transaction) ([]string, error) {
+	hash := RawDataHash(tx)
+	if hash == nil || tx.GetRawData() == nil {
+		return nil, ErrBadTransactionParam
+	}
+
+	signers := make([]string, 0, len(tx.GetSignature()))
+	signed := make(map[string]bool)
+	for i, sig := range tx.GetSignature() {
+		signer, err := signatureSigner(hash, sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %v", i, err)
+		}
+		if signed[signer] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateSigner, signer)
+		}
+		signed[signer] = true
+		signers = append(signers, signer)
+	}
+
+	for _, contract := range tx.GetRawData().GetContract() {
+		if contract.GetPermissionId() != 0 {
+			continue
+		}
+		owner, err := contractOwner(contract)
+		if err != nil {
+			return nil, err
+		}
+		if !signed[address.Address(owner).String()] {
+			return signers, fmt.Errorf("%w: %s", ErrOwnerNotSigned, address.Address(owner).String())
+		}
+	}
+	return signers, nil
+}
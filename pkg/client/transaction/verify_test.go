@@ -0,0 +1,51 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignatures(t *testing.T) {
+	ownerKey := bytes.Repeat([]byte{0x01}, 32)
+	otherKey := bytes.Repeat([]byte{0x02}, 32)
+	sk, err := crypto.ToECDSA(ownerKey)
+	require.Nil(t, err)
+	owner := address.PubkeyToAddress(sk.PublicKey).String()
+
+	tx, err := BuildTransfer(owner, "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9",
+		1000000, testRefBlock, time.UnixMilli(1700000060000))
+	require.Nil(t, err)
+	txID, err := transactionID(tx)
+	require.Nil(t, err)
+	assert.Equal(t, txID, common.BytesToHexString(RawDataHash(tx)))
+
+	_, err = VerifySignatures(tx)
+	assert.True(t, errors.Is(err, ErrOwnerNotSigned))
+
+	ctrlr := NewController(nil, nil, nil, tx)
+	require.Nil(t, ctrlr.Sign([][]byte{otherKey}))
+	_, err = VerifySignatures(tx)
+	assert.True(t, errors.Is(err, ErrOwnerNotSigned))
+
+	require.Nil(t, ctrlr.Sign([][]byte{ownerKey}))
+	signers, err := VerifySignatures(tx)
+	require.Nil(t, err)
+	require.Len(t, signers, 2)
+	assert.Equal(t, owner, signers[1])
+
+	tx.Signature = append(tx.Signature, tx.Signature[1])
+	_, err = VerifySignatures(tx)
+	assert.True(t, errors.Is(err, ErrDuplicateSigner))
+
+	tx.Signature = [][]byte{tx.Signature[1][:64]}
+	_, err = VerifySignatures(tx)
+	assert.Error(t, err)
+}
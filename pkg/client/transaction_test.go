@@ -0,0 +1,23 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionMemo(t *testing.T) {
+	memo, ok := transactionMemo(&core.Transaction{RawData: &core.TransactionRaw{Data: []byte("invoice #42")}})
+	assert.True(t, ok)
+	assert.Equal(t, "invoice #42", memo)
+
+	memo, ok = transactionMemo(&core.Transaction{RawData: &core.TransactionRaw{Data: []byte{0xff, 0x01}}})
+	assert.True(t, ok)
+	assert.Equal(t, "0xff01", memo)
+
+	_, ok = transactionMemo(&core.Transaction{RawData: &core.TransactionRaw{}})
+	assert.False(t, ok)
+	_, ok = transactionMemo(&core.Transaction{})
+	assert.False(t, ok)
+}
@@ -20,9 +20,17 @@ const (
 	trc20NameSignature           = "0x06fdde03"
 	trc20SymbolSignature         = "0x95d89b41"
 	trc20DecimalsSignature       = "0x313ce567"
+	trc20TotalSupplySignature    = "0x18160ddd"
 	trc20BalanceOf               = "0x70a08231"
+	trc20AllowanceSignature      = "0xdd62ed3e"
 )
 
+// TRC20MaxAllowance returns max uint256, the unlimited approval granted to
+// routers, a new value on each call
+func TRC20MaxAllowance() *big.Int {
+	return new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+}
+
 // TRC20Call make cosntant calll
 func (g *GrpcClient) TRC20Call(from, contractAddress, data string, constant bool, feeLimit int64) (*api.TransactionExtention, error) {
 	var err error
@@ -166,6 +174,37 @@ func (g *GrpcClient) TRC20ContractBalance(addr, contractAddress string) (*big.In
 	return r, nil
 }
 
+// TRC20Allowance get amount spender is allowed to transfer from owner
+func (g *GrpcClient) TRC20Allowance(owner, spender, contractAddress string) (*big.Int, error) {
+	ownerB, err := address.Base58ToAddress(owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %v", owner, err)
+	}
+	spenderB, err := address.Base58ToAddress(spender)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %v", spender, err)
+	}
+	req := trc20AllowanceSignature +
+		"0000000000000000000000000000000000000000000000000000000000000000"[len(ownerB.Hex())-4:] + ownerB.Hex()[4:] +
+		"0000000000000000000000000000000000000000000000000000000000000000"[len(spenderB.Hex())-4:] + spenderB.Hex()[4:]
+	result, err := g.TRC20Call("", contractAddress, req, true, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !constantCallReturned(result) {
+		return nil, fmt.Errorf("contract address %s: allowance call returned no value", contractAddress)
+	}
+	data := common.BytesToHexString(result.GetConstantResult()[0])
+	r, err := g.ParseTRC20NumericProperty(data)
+	if err != nil {
+		return nil, fmt.Errorf("contract address %s: %v", contractAddress, err)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("contract address %s: invalid allowance of %s", contractAddress, owner)
+	}
+	return r, nil
+}
+
 // TRC20Send send token to address
 func (g *GrpcClient) TRC20Send(from, to, contract string, amount *big.Int, feeLimit int64) (*api.TransactionExtention, error) {
 	addrB, err := address.Base58ToAddress(to)
@@ -21,3 +21,13 @@ func TestTRC20_Balance(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Greater(t, balance.Int64(), int64(0))
 }
+
+func TestTRC20MaxAllowance(t *testing.T) {
+	max := client.TRC20MaxAllowance()
+	assert.Equal(t, 256, max.BitLen())
+	assert.NotContains(t, max.Text(2), "0")
+
+	// callers can not change the value others get
+	max.SetInt64(0)
+	assert.Equal(t, 256, client.TRC20MaxAllowance().BitLen())
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// TRC20BalanceResult balance of one address read by TRC20BatchBalances, Err
+// is set instead of Balance when the query failed
+type TRC20BalanceResult struct {
+	Address string
+	Balance *big.Int
+	Err     error
+}
+
+// TRC20BatchBalances reads the contractAddress balance of every address with
+// up to concurrency calls in flight. Results follow the order of addresses
+// and a failed address does not stop the others.
+func (g *GrpcClient) TRC20BatchBalances(addresses []string, contractAddress string, concurrency int) ([]TRC20BalanceResult, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("invalid concurrency: %d", concurrency)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]TRC20BalanceResult, len(addresses))
+		sem     = make(chan struct{}, concurrency)
+	)
+	for i, addr := range addresses {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, addr string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			balance, err := g.TRC20ContractBalance(addr, contractAddress)
+			results[i] = TRC20BalanceResult{Address: addr, Balance: balance, Err: err}
+		}(i, addr)
+	}
+	wg.Wait()
+	return results, nil
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTRC20BatchBalancesInvalid(t *testing.T) {
+	g := NewGrpcClient("")
+	_, err := g.TRC20BatchBalances([]string{"a"}, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", 0)
+	assert.Error(t, err)
+
+	// invalid addresses fail before reaching the node, in input order
+	addresses := []string{"not-an-address", "", "T123"}
+	results, err := g.TRC20BatchBalances(addresses, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", 2)
+	require.Nil(t, err)
+	require.Len(t, results, len(addresses))
+	for i, result := range results {
+		assert.Equal(t, addresses[i], result.Address)
+		assert.Nil(t, result.Balance)
+		assert.Error(t, result.Err)
+	}
+}
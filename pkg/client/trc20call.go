@@ -0,0 +1,41 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+)
+
+// trc20TransferAndCallMethod ERC-677 style transfer notifying the recipient
+const trc20TransferAndCallMethod = "transferAndCall(address,uint256,bytes)"
+
+// TRC20TransferAndCall sends amount to the contract to and has the token call
+// it back with data in the same transaction, as expected by some routers.
+// It fails with ErrNotAContract when to has no code.
+func (g *GrpcClient) TRC20TransferAndCall(from, to, contract string, amount *big.Int, data []byte, feeLimit int64) (*api.TransactionExtention, error) {
+	if _, err := g.GetSmartContractByteCode(to); err != nil {
+		if errors.Is(err, ErrNotAContract) {
+			return nil, fmt.Errorf("%w: %s", ErrNotAContract, to)
+		}
+		return nil, err
+	}
+	req, err := trc20TransferAndCallData(to, amount, data)
+	if err != nil {
+		return nil, err
+	}
+	return g.TRC20Call(from, contract, common.BytesToHexString(req), false, feeLimit)
+}
+
+// trc20TransferAndCallData ABI encodes transferAndCall(to, amount, data)
+func trc20TransferAndCallData(to string, amount *big.Int, data []byte) ([]byte, error) {
+	return abi.Pack(trc20TransferAndCallMethod, []abi.Param{
+		{"address": to},
+		{"uint256": amount.String()},
+		{"bytes": hex.EncodeToString(data)},
+	})
+}
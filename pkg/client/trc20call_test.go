@@ -0,0 +1,28 @@
+package client
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTRC20TransferAndCallData(t *testing.T) {
+	to := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	toAddr, err := address.Base58ToAddress(to)
+	require.Nil(t, err)
+
+	data, err := trc20TransferAndCallData(to, big.NewInt(1000), []byte{0xca, 0xfe})
+	require.Nil(t, err)
+	require.Len(t, data, 4+5*32)
+
+	assert.Equal(t, "4000aea0", hex.EncodeToString(data[:4]))
+	assert.Equal(t, toAddr.Bytes()[1:], data[4+12:4+32])
+	assert.Equal(t, int64(1000), new(big.Int).SetBytes(data[4+32:4+64]).Int64())
+	assert.Equal(t, int64(0x60), new(big.Int).SetBytes(data[4+64:4+96]).Int64())
+	assert.Equal(t, int64(2), new(big.Int).SetBytes(data[4+96:4+128]).Int64())
+	assert.Equal(t, []byte{0xca, 0xfe}, data[4+128:4+130])
+}
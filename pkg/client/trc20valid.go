@@ -0,0 +1,49 @@
+package client
+
+import (
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// trc20ValidTTL how long IsValidTRC20Address remembers a positive answer
+const trc20ValidTTL = 5 * time.Minute
+
+// IsValidTRC20Address reports whether contractAddress answers totalSupply()
+// and decimals() with data, telling TRC20 tokens apart from other contracts
+// and plain accounts. Positive answers are cached for 5 minutes, errors are
+// only returned when the node can not be queried.
+func (g *GrpcClient) IsValidTRC20Address(contractAddress string) (bool, error) {
+	if expiry, ok := g.trc20Valid.Load(contractAddress); ok && time.Now().Before(expiry.(time.Time)) {
+		return true, nil
+	}
+	if _, err := address.Base58ToAddress(contractAddress); err != nil {
+		return false, err
+	}
+	for _, method := range []string{trc20TotalSupplySignature, trc20DecimalsSignature} {
+		result, err := g.TRC20Call("", contractAddress, method, true, 0)
+		if err != nil && result == nil {
+			return false, err
+		}
+		if err != nil || !constantCallReturned(result) {
+			return false, nil
+		}
+	}
+	g.trc20Valid.Store(contractAddress, time.Now().Add(trc20ValidTTL))
+	return true, nil
+}
+
+// constantCallReturned reports whether a constant call completed and returned
+// at least one ABI word
+func constantCallReturned(result *api.TransactionExtention) bool {
+	for _, ret := range result.GetTransaction().GetRet() {
+		if ret.GetContractRet() != core.Transaction_Result_DEFAULT &&
+			ret.GetContractRet() != core.Transaction_Result_SUCCESS {
+			return false
+		}
+	}
+	constant := result.GetConstantResult()
+	return len(constant) > 0 && len(constant[0]) >= 32
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantCallReturned(t *testing.T) {
+	word := make([]byte, 32)
+	assert.True(t, constantCallReturned(&api.TransactionExtention{ConstantResult: [][]byte{word}}))
+	assert.True(t, constantCallReturned(&api.TransactionExtention{
+		ConstantResult: [][]byte{word},
+		Transaction: &core.Transaction{Ret: []*core.Transaction_Result{
+			{ContractRet: core.Transaction_Result_SUCCESS},
+		}},
+	}))
+
+	// plain accounts and contracts without the method return nothing
+	assert.False(t, constantCallReturned(&api.TransactionExtention{}))
+	assert.False(t, constantCallReturned(&api.TransactionExtention{ConstantResult: [][]byte{{}}}))
+	assert.False(t, constantCallReturned(&api.TransactionExtention{
+		ConstantResult: [][]byte{word},
+		Transaction: &core.Transaction{Ret: []*core.Transaction_Result{
+			{ContractRet: core.Transaction_Result_REVERT},
+		}},
+	}))
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
@@ -152,3 +154,59 @@ func (g *GrpcClient) UpdateBrokerage(from string, comission int32) (*api.Transac
 	}
 	return tx, nil
 }
+
+// ErrWitnessNotFound is returned when an address is not a witness candidate
+var ErrWitnessNotFound = errors.New("witness not found")
+
+// WitnessStats production figures of a witness
+type WitnessStats struct {
+	Address       string
+	TotalProduced int64
+	TotalMissed   int64
+	VoteCount     int64
+	URL           string
+	IsJobs        bool
+	LastBlockNum  int64
+	// Brokerage percentage of rewards kept by the witness
+	Brokerage float64
+}
+
+// GetWitnessStats returns production figures and brokerage of srAddr, the
+// node has no single witness query so the witness list is filtered
+func (g *GrpcClient) GetWitnessStats(srAddr string) (*WitnessStats, error) {
+	addr, err := common.DecodeCheck(srAddr)
+	if err != nil {
+		return nil, err
+	}
+	list, err := g.ListWitnesses()
+	if err != nil {
+		return nil, err
+	}
+	witness := findWitness(list, addr)
+	if witness == nil {
+		return nil, fmt.Errorf("%w: %s", ErrWitnessNotFound, srAddr)
+	}
+	brokerage, err := g.GetWitnessBrokerage(srAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &WitnessStats{
+		Address:       srAddr,
+		TotalProduced: witness.GetTotalProduced(),
+		TotalMissed:   witness.GetTotalMissed(),
+		VoteCount:     witness.GetVoteCount(),
+		URL:           witness.GetUrl(),
+		IsJobs:        witness.GetIsJobs(),
+		LastBlockNum:  witness.GetLatestBlockNum(),
+		Brokerage:     brokerage,
+	}, nil
+}
+
+func findWitness(list *api.WitnessList, addr []byte) *core.Witness {
+	for _, witness := range list.GetWitnesses() {
+		if bytes.Equal(witness.GetAddress(), addr) {
+			return witness
+		}
+	}
+	return nil
+}
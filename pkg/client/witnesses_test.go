@@ -0,0 +1,28 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindWitness(t *testing.T) {
+	sr, err := common.DecodeCheck("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	require.Nil(t, err)
+	other, err := common.DecodeCheck("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	require.Nil(t, err)
+	list := &api.WitnessList{Witnesses: []*core.Witness{
+		{Address: other, TotalProduced: 1},
+		{Address: sr, TotalProduced: 2},
+	}}
+
+	witness := findWitness(list, sr)
+	if assert.NotNil(t, witness) {
+		assert.Equal(t, int64(2), witness.TotalProduced)
+	}
+	assert.Nil(t, findWitness(&api.WitnessList{}, sr))
+}
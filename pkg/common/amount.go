@@ -0,0 +1,51 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidAmount is returned for amounts that can not be converted exactly
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// ParseAmount converts a decimal string such as "1.5" into integer base units
+// with the given decimals, e.g. ParseAmount("1.5", 6) is 1500000. Only plain
+// non negative decimals are accepted: signs, exponents, separators and more
+// fractional digits than decimals are rejected instead of being rounded.
+func ParseAmount(amount string, decimals int) (*big.Int, error) {
+	if decimals < 0 {
+		return nil, fmt.Errorf("%w: negative decimals %d", ErrInvalidAmount, decimals)
+	}
+	whole, fraction, hasPoint := strings.Cut(amount, ".")
+	if len(whole) == 0 || (hasPoint && len(fraction) == 0) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	for _, part := range []string{whole, fraction} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+			}
+		}
+	}
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("%w: %q has more than %d decimals", ErrInvalidAmount, amount, decimals)
+	}
+
+	value, _ := new(big.Int).SetString(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	return value, nil
+}
+
+// ParseAmountInt64 is ParseAmount for values that must fit an int64,
+// e.g. TRX amounts in SUN with AmountDecimalPoint decimals
+func ParseAmountInt64(amount string, decimals int) (int64, error) {
+	value, err := ParseAmount(amount, decimals)
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("%w: %q overflows int64", ErrInvalidAmount, amount)
+	}
+	return value.Int64(), nil
+}
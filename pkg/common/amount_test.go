@@ -0,0 +1,45 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	valid := map[string]string{
+		"1":          "1000000",
+		"1.5":        "1500000",
+		"0.000001":   "1",
+		"007.10":     "7100000",
+		"1234567890": "1234567890000000",
+	}
+	for in, want := range valid {
+		value, err := common.ParseAmount(in, 6)
+		require.Nil(t, err, in)
+		assert.Equal(t, want, value.String(), in)
+	}
+
+	for _, in := range []string{"", ".5", "1.", "-1", "+1", "1e6", "1,000", " 1", "1.0000001", "0x10", "1.2.3"} {
+		_, err := common.ParseAmount(in, 6)
+		assert.True(t, errors.Is(err, common.ErrInvalidAmount), in)
+	}
+
+	value, err := common.ParseAmount("12", 0)
+	require.Nil(t, err)
+	assert.Equal(t, "12", value.String())
+	_, err = common.ParseAmount("1.2", 0)
+	assert.Error(t, err)
+}
+
+func TestParseAmountInt64(t *testing.T) {
+	value, err := common.ParseAmountInt64("0.1", 6)
+	require.Nil(t, err)
+	assert.Equal(t, int64(100000), value)
+
+	_, err = common.ParseAmountInt64("9223372036854.775808", 6)
+	assert.True(t, errors.Is(err, common.ErrInvalidAmount))
+}
@@ -0,0 +1,136 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FormatTransaction renders tx as multi-line text for humans: contracts with
+// their fields, fee limit, expiration, reference block and the addresses
+// recovered from each signature. No node connection is needed.
+func FormatTransaction(tx *core.Transaction) string {
+	var sb strings.Builder
+	raw := tx.GetRawData()
+	rawData, _ := proto.Marshal(raw)
+	hash := sha256.Sum256(rawData)
+
+	fmt.Fprintf(&sb, "TxID:        %s\n", BytesToHexString(hash[:]))
+	for i, contract := range raw.GetContract() {
+		fmt.Fprintf(&sb, "Contract %d:  %s\n", i, contract.GetType().String())
+		if contract.GetPermissionId() > 0 {
+			fmt.Fprintf(&sb, "  permission_id: %d\n", contract.GetPermissionId())
+		}
+		msg, err := contract.GetParameter().UnmarshalNew()
+		if err != nil {
+			fmt.Fprintf(&sb, "  <%v>\n", err)
+			continue
+		}
+		msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			fmt.Fprintf(&sb, "  %s: %s\n", fd.Name(), formatField(fd, v))
+			return true
+		})
+	}
+	if raw.GetFeeLimit() > 0 {
+		fmt.Fprintf(&sb, "Fee limit:   %d SUN\n", raw.GetFeeLimit())
+	}
+	if len(raw.GetData()) > 0 {
+		fmt.Fprintf(&sb, "Memo:        %q\n", string(raw.GetData()))
+	}
+	fmt.Fprintf(&sb, "Timestamp:   %s\n", formatMillis(raw.GetTimestamp()))
+	fmt.Fprintf(&sb, "Expiration:  %s\n", formatMillis(raw.GetExpiration()))
+	if len(raw.GetRefBlockBytes()) == 2 {
+		fmt.Fprintf(&sb, "Ref block:   #...%d (bytes %s, hash %s)\n",
+			binary.BigEndian.Uint16(raw.GetRefBlockBytes()),
+			BytesToHexString(raw.GetRefBlockBytes()), BytesToHexString(raw.GetRefBlockHash()))
+	}
+	if len(tx.GetSignature()) == 0 {
+		sb.WriteString("Signatures:  none\n")
+	}
+	for i, sig := range tx.GetSignature() {
+		signer, err := recoverSigner(hash[:], sig)
+		if err != nil {
+			fmt.Fprintf(&sb, "Signature %d: <%v>\n", i, err)
+			continue
+		}
+		fmt.Fprintf(&sb, "Signature %d: %s\n", i, signer)
+	}
+	return sb.String()
+}
+
+func formatField(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch {
+	case fd.IsList():
+		items := make([]string, 0, v.List().Len())
+		for i := 0; i < v.List().Len(); i++ {
+			items = append(items, formatSingle(fd, v.List().Get(i)))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case fd.IsMap():
+		items := make([]string, 0, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			items = append(items, fmt.Sprintf("%v: %s", k.Interface(), formatSingle(fd.MapValue(), mv)))
+			return true
+		})
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return formatSingle(fd, v)
+}
+
+func formatSingle(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.BytesKind:
+		b := v.Bytes()
+		if len(b) == 21 && b[0] == 0x41 {
+			return EncodeCheck(b)
+		}
+		return BytesToHexString(b)
+	case protoreflect.MessageKind:
+		fields := make([]string, 0)
+		v.Message().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			fields = append(fields, fmt.Sprintf("%s: %s", fd.Name(), formatField(fd, v)))
+			return true
+		})
+		return "{" + strings.Join(fields, ", ") + "}"
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+func formatMillis(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+// recoverSigner returns the base58 address that produced sig over hash
+func recoverSigner(hash, sig []byte) (string, error) {
+	if len(sig) != 65 {
+		return "", fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	// Tron signatures use v 27/28 while recovery expects 0/1
+	rsv := CopyBytes(sig)
+	if rsv[64] >= 27 {
+		rsv[64] -= 27
+	}
+	pub, err := crypto.Ecrecover(hash, rsv)
+	if err != nil {
+		return "", err
+	}
+	if len(pub) != 65 {
+		return "", fmt.Errorf("invalid public key")
+	}
+	addr := append([]byte{0x41}, Keccak256(pub[1:])[12:]...)
+	return EncodeCheck(addr), nil
+}
@@ -0,0 +1,38 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestFormatTransaction(t *testing.T) {
+	owner, _ := common.DecodeCheck("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	to, _ := common.DecodeCheck("TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9")
+	param, err := anypb.New(&core.TransferContract{OwnerAddress: owner, ToAddress: to, Amount: 1500000})
+	require.Nil(t, err)
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		RefBlockBytes: []byte{0x4c, 0x5d},
+		RefBlockHash:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Expiration:    1700000060000,
+		Data:          []byte("invoice 42"),
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_TransferContract,
+			Parameter: param,
+		}},
+	}}
+
+	out := common.FormatTransaction(tx)
+	assert.Contains(t, out, "Contract 0:  TransferContract\n")
+	assert.Contains(t, out, "  owner_address: TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b\n")
+	assert.Contains(t, out, "  to_address: TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9\n")
+	assert.Contains(t, out, "  amount: 1500000\n")
+	assert.Contains(t, out, "Memo:        \"invoice 42\"\n")
+	assert.Contains(t, out, "Expiration:  2023-11-14T22:14:20Z\n")
+	assert.Contains(t, out, "Ref block:   #...19549 (bytes 0x4c5d, hash 0x0102030405060708)\n")
+	assert.Contains(t, out, "Signatures:  none\n")
+}
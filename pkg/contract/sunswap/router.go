@@ -0,0 +1,99 @@
+// Package sunswap interacts with the SunSwap V2 router, an Uniswap V2 fork
+package sunswap
+
+import (
+	"fmt"
+	"math/big"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+)
+
+const (
+	// RouterAddress SunSwap V2 router on mainnet
+	RouterAddress = "TKzxdSv2FZKQrEqkKVgp5DcwEXBEKMg2Ax"
+
+	getAmountsOutMethod            = "getAmountsOut(uint256,address[])"
+	swapExactTokensForTokensMethod = "swapExactTokensForTokens(uint256,uint256,address[],address,uint256)"
+)
+
+// Router SunSwap V2 router contract
+type Router struct {
+	client   *client.GrpcClient
+	Address  string
+	FeeLimit int64
+}
+
+// NewRouter for the router deployed at routerAddress
+func NewRouter(c *client.GrpcClient, routerAddress string) *Router {
+	return &Router{
+		client:   c,
+		Address:  routerAddress,
+		FeeLimit: 100000000,
+	}
+}
+
+// GetAmountOut returns how much of the last token in path is received for amountIn
+// of the first one
+func (r *Router) GetAmountOut(amountIn *big.Int, path []string) (*big.Int, error) {
+	if len(path) < 2 {
+		return nil, fmt.Errorf("invalid path: at least two tokens required")
+	}
+	data, err := abi.Pack(getAmountsOutMethod, []abi.Param{
+		{"uint256": amountIn},
+		{"address[]": addressList(path)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	tx, err := r.client.TRC20Call("", r.Address, common.BytesToHexString(data), true, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.GetConstantResult()) == 0 {
+		return nil, fmt.Errorf("empty result from router %s", r.Address)
+	}
+
+	ty, _ := eABI.NewType("uint256[]", "", nil)
+	values, err := eABI.Arguments{{Type: ty}}.Unpack(tx.GetConstantResult()[0])
+	if err != nil {
+		return nil, err
+	}
+	amounts, ok := values[0].([]*big.Int)
+	if !ok || len(amounts) != len(path) {
+		return nil, fmt.Errorf("invalid amounts returned by router %s", r.Address)
+	}
+	return amounts[len(amounts)-1], nil
+}
+
+// SwapExactTokensForTokens builds the swap of amountIn of the first token in path
+// for at least minOut of the last one, sent to `to` before the deadline (unix seconds).
+// The router must be approved to spend amountIn from `from`.
+func (r *Router) SwapExactTokensForTokens(from string, amountIn, minOut *big.Int,
+	path []string, to string, deadline int64) (*api.TransactionExtention, error) {
+	if len(path) < 2 {
+		return nil, fmt.Errorf("invalid path: at least two tokens required")
+	}
+	data, err := abi.Pack(swapExactTokensForTokensMethod, []abi.Param{
+		{"uint256": amountIn},
+		{"uint256": minOut},
+		{"address[]": addressList(path)},
+		{"address": to},
+		{"uint256": big.NewInt(deadline)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.client.TRC20Call(from, r.Address, common.BytesToHexString(data), false, r.FeeLimit)
+}
+
+func addressList(path []string) []interface{} {
+	list := make([]interface{}, len(path))
+	for i := range path {
+		list[i] = path[i]
+	}
+	return list
+}
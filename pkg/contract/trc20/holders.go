@@ -0,0 +1,167 @@
+// Package trc20 works with TRC20 token contracts beyond single calls
+package trc20
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// transferTopic keccak256 of Transfer(address,address,uint256)
+var transferTopic, _ = hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+// zeroAddress source of mints and destination of burns, never a holder
+var zeroAddress = make([]byte, 20)
+
+// Checkpoint state of a HolderScan, saved by callers to resume a long scan
+type Checkpoint struct {
+	Contract  string              `json:"contract"`
+	NextBlock int64               `json:"nextBlock"`
+	Balances  map[string]*big.Int `json:"balances"`
+}
+
+// Holder account with a non zero token balance
+type Holder struct {
+	Address string
+	Balance *big.Int
+}
+
+// HolderScan rebuilds the holders of a token by replaying its Transfer events
+// block by block. Each block costs one node call, so scans from deployment
+// take long: run Scan over ranges and store Checkpoint in between.
+type HolderScan struct {
+	client   *client.GrpcClient
+	contract []byte
+	state    Checkpoint
+}
+
+// NewHolderScan starts a scan of contract at startBlock, its deployment block
+// or earlier, e.g. found with GrpcClient.GetBlockNumberAt
+func NewHolderScan(c *client.GrpcClient, contract string, startBlock int64) (*HolderScan, error) {
+	return ResumeHolderScan(c, Checkpoint{
+		Contract:  contract,
+		NextBlock: startBlock,
+		Balances:  make(map[string]*big.Int),
+	})
+}
+
+// ResumeHolderScan continues a scan from a saved checkpoint
+func ResumeHolderScan(c *client.GrpcClient, cp Checkpoint) (*HolderScan, error) {
+	contract, err := common.DecodeCheck(cp.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract: %v", err)
+	}
+	scan := &HolderScan{
+		client:   c,
+		contract: contract[1:],
+		state: Checkpoint{
+			Contract:  cp.Contract,
+			NextBlock: cp.NextBlock,
+			Balances:  make(map[string]*big.Int, len(cp.Balances)),
+		},
+	}
+	for addr, balance := range cp.Balances {
+		scan.state.Balances[addr] = new(big.Int).Set(balance)
+	}
+	return scan, nil
+}
+
+// Scan replays blocks up to end, both included. It stops between blocks when
+// ctx is done, so the checkpoint stays consistent.
+func (s *HolderScan) Scan(ctx context.Context, end int64) error {
+	for s.state.NextBlock <= end {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		infos, err := s.client.GetBlockInfoByNum(s.state.NextBlock)
+		if err != nil {
+			return fmt.Errorf("block %d: %v", s.state.NextBlock, err)
+		}
+		s.apply(infos.GetTransactionInfo())
+		s.state.NextBlock++
+	}
+	return nil
+}
+
+// apply updates balances with the Transfer events of the contract in infos
+func (s *HolderScan) apply(infos []*core.TransactionInfo) {
+	for _, info := range infos {
+		for _, log := range info.GetLog() {
+			from, to, value, ok := parseTransfer(log, s.contract)
+			if !ok {
+				continue
+			}
+			s.add(from, new(big.Int).Neg(value))
+			s.add(to, value)
+		}
+	}
+}
+
+// parseTransfer returns the 20 bytes from and to addresses and the value of
+// a Transfer event emitted by contract. Non standard tokens not indexing from
+// and to are not recognized.
+func parseTransfer(log *core.TransactionInfo_Log, contract []byte) ([]byte, []byte, *big.Int, bool) {
+	topics := log.GetTopics()
+	if !bytes.Equal(log.GetAddress(), contract) || len(topics) != 3 ||
+		!bytes.Equal(topics[0], transferTopic) || len(log.GetData()) != 32 {
+		return nil, nil, nil, false
+	}
+	return topics[1][12:], topics[2][12:], new(big.Int).SetBytes(log.GetData()), true
+}
+
+func (s *HolderScan) add(addr []byte, value *big.Int) {
+	if bytes.Equal(addr, zeroAddress) {
+		return
+	}
+	key := common.EncodeCheck(append([]byte{0x41}, addr...))
+	balance, ok := s.state.Balances[key]
+	if !ok {
+		balance = new(big.Int)
+		s.state.Balances[key] = balance
+	}
+	balance.Add(balance, value)
+	if balance.Sign() == 0 {
+		delete(s.state.Balances, key)
+	}
+}
+
+// NextBlock first block not yet scanned
+func (s *HolderScan) NextBlock() int64 {
+	return s.state.NextBlock
+}
+
+// Checkpoint returns a copy of the scan state to resume it later
+func (s *HolderScan) Checkpoint() Checkpoint {
+	cp := Checkpoint{
+		Contract:  s.state.Contract,
+		NextBlock: s.state.NextBlock,
+		Balances:  make(map[string]*big.Int, len(s.state.Balances)),
+	}
+	for addr, balance := range s.state.Balances {
+		cp.Balances[addr] = new(big.Int).Set(balance)
+	}
+	return cp
+}
+
+// Holders returns accounts holding tokens as of the last scanned block,
+// largest balance first
+func (s *HolderScan) Holders() []Holder {
+	holders := make([]Holder, 0, len(s.state.Balances))
+	for addr, balance := range s.state.Balances {
+		holders = append(holders, Holder{Address: addr, Balance: new(big.Int).Set(balance)})
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if c := holders[i].Balance.Cmp(holders[j].Balance); c != 0 {
+			return c > 0
+		}
+		return holders[i].Address < holders[j].Address
+	})
+	return holders
+}
@@ -0,0 +1,81 @@
+package trc20
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testToken = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	testAlice = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
+	testBob   = "TMuA6YqfCeX8EhbfYEg5y7S4DqzSJireY9"
+)
+
+func transferLog(t *testing.T, contract, from, to string, value int64) *core.TransactionInfo_Log {
+	topic := func(addr string) []byte {
+		if addr == "" {
+			return make([]byte, 32)
+		}
+		b, err := common.DecodeCheck(addr)
+		require.Nil(t, err)
+		return common.LeftPadBytes(b[1:], 32)
+	}
+	c, err := common.DecodeCheck(contract)
+	require.Nil(t, err)
+	return &core.TransactionInfo_Log{
+		Address: c[1:],
+		Topics:  [][]byte{transferTopic, topic(from), topic(to)},
+		Data:    common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+	}
+}
+
+func TestHolderScanApply(t *testing.T) {
+	scan, err := NewHolderScan(nil, testToken, 100)
+	require.Nil(t, err)
+
+	scan.apply([]*core.TransactionInfo{
+		{Log: []*core.TransactionInfo_Log{
+			transferLog(t, testToken, "", testAlice, 1000),
+			transferLog(t, testToken, testAlice, testBob, 300),
+		}},
+		{Log: []*core.TransactionInfo_Log{
+			// other contracts are ignored
+			transferLog(t, testBob, testAlice, testBob, 700),
+			transferLog(t, testToken, testBob, "", 300),
+		}},
+	})
+
+	holders := scan.Holders()
+	require.Len(t, holders, 1)
+	assert.Equal(t, testAlice, holders[0].Address)
+	assert.Equal(t, int64(700), holders[0].Balance.Int64())
+}
+
+func TestHolderScanCheckpoint(t *testing.T) {
+	scan, err := NewHolderScan(nil, testToken, 100)
+	require.Nil(t, err)
+	scan.apply([]*core.TransactionInfo{{Log: []*core.TransactionInfo_Log{
+		transferLog(t, testToken, "", testAlice, 1000),
+		transferLog(t, testToken, testAlice, testBob, 400),
+	}}})
+	// nothing to scan, the range ends before the next block
+	require.Nil(t, scan.Scan(context.Background(), 99))
+
+	data, err := json.Marshal(scan.Checkpoint())
+	require.Nil(t, err)
+	var cp Checkpoint
+	require.Nil(t, json.Unmarshal(data, &cp))
+
+	resumed, err := ResumeHolderScan(nil, cp)
+	require.Nil(t, err)
+	assert.Equal(t, int64(100), resumed.NextBlock())
+	assert.Equal(t, scan.Holders(), resumed.Holders())
+	assert.Equal(t, testAlice, resumed.Holders()[0].Address)
+}
@@ -0,0 +1,235 @@
+package trc20
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// watchPollInterval time between new block checks of WatchTransfers, about
+// one block
+const watchPollInterval = 3 * time.Second
+
+// DefaultConfirmations blocks WatchTransfers stays behind the head without a
+// solidity node, the blocks 2/3 of the 27 witnesses take to solidify one
+const DefaultConfirmations = 19
+
+// Client TRC20 token contract
+type Client struct {
+	client   *client.GrpcClient
+	Address  string
+	contract []byte
+	// Confirmations blocks behind the head a block must be before
+	// WatchTransfers emits its transfers, when the connection has no
+	// solidity node
+	Confirmations int64
+	// Checkpoints last block fully processed by WatchTransfers, by block
+	// number, holding the number of transfers emitted for it. Older blocks
+	// are pruned.
+	Checkpoints  sync.Map
+	pollInterval time.Duration
+}
+
+// TransferFilter selects the transfers WatchTransfers emits, empty fields
+// match any transfer. FromBlock is the first block to watch, zero for the
+// current head, e.g. LastCheckpoint()+1 to resume.
+type TransferFilter struct {
+	From      string
+	To        string
+	MinAmount *big.Int
+	FromBlock int64
+}
+
+// Transfer event of the token
+type Transfer struct {
+	TxID     string
+	BlockNum int64
+	// LogIndex position of the event in the transaction logs
+	LogIndex int
+	From     string
+	To       string
+	Amount   *big.Int
+}
+
+// NewClient for the token deployed at contract
+func NewClient(c *client.GrpcClient, contract string) (*Client, error) {
+	addr, err := common.DecodeCheck(contract)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract: %v", err)
+	}
+	return &Client{
+		client:        c,
+		Address:       contract,
+		contract:      addr[1:],
+		Confirmations: DefaultConfirmations,
+		pollInterval:  watchPollInterval,
+	}, nil
+}
+
+// LastCheckpoint returns the highest block in Checkpoints, false when none
+func (c *Client) LastCheckpoint() (int64, bool) {
+	var (
+		last  int64
+		found bool
+	)
+	c.Checkpoints.Range(func(key, _ interface{}) bool {
+		if block := key.(int64); !found || block > last {
+			last, found = block, true
+		}
+		return true
+	})
+	return last, found
+}
+
+// WatchTransfers polls new blocks and emits the token transfers matching
+// filter, in chain order. Only irreversible blocks are read: up to the
+// solidity head when the connection has a solidity node, see StartSolidity,
+// otherwise up to Confirmations blocks behind the head. Each block is
+// checkpointed once all its transfers are emitted. Node errors are sent on
+// the error channel and the block is retried on the next poll, both channels
+// must be read. They are closed once ctx is done.
+func (c *Client) WatchTransfers(ctx context.Context, filter TransferFilter) (<-chan Transfer, <-chan error) {
+	transfers := make(chan Transfer)
+	errs := make(chan error)
+
+	go func() {
+		defer close(transfers)
+		defer close(errs)
+
+		sendErr := func(err error) bool {
+			select {
+			case errs <- err:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		if err := filter.validate(); err != nil {
+			sendErr(err)
+			return
+		}
+
+		next := filter.FromBlock
+		ticker := time.NewTicker(c.pollInterval)
+		defer ticker.Stop()
+		for {
+			headNum, err := c.confirmedHead()
+			if err != nil {
+				if !sendErr(err) {
+					return
+				}
+			} else {
+				if next == 0 && headNum > 0 {
+					next = headNum
+				}
+				for ; next <= headNum; next++ {
+					infos, err := c.client.GetBlockInfoByNum(next)
+					if err != nil {
+						if !sendErr(fmt.Errorf("block %d: %v", next, err)) {
+							return
+						}
+						break
+					}
+					matched := 0
+					for _, t := range c.transfers(next, infos.GetTransactionInfo()) {
+						if !filter.match(t) {
+							continue
+						}
+						select {
+						case transfers <- t:
+							matched++
+						case <-ctx.Done():
+							return
+						}
+					}
+					c.checkpoint(next, matched)
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return transfers, errs
+}
+
+// confirmedHead last block whose transfers can be emitted
+func (c *Client) confirmedHead() (int64, error) {
+	if c.client.Solidity != nil {
+		head, err := c.client.GetNowBlockSolidity()
+		if err != nil {
+			return 0, err
+		}
+		return head.GetBlockHeader().GetRawData().GetNumber(), nil
+	}
+	head, err := c.client.GetNowBlock()
+	if err != nil {
+		return 0, err
+	}
+	return head.GetBlockHeader().GetRawData().GetNumber() - c.Confirmations, nil
+}
+
+// checkpoint stores block as processed and prunes the older checkpoints
+func (c *Client) checkpoint(block int64, matched int) {
+	c.Checkpoints.Store(block, matched)
+	c.Checkpoints.Range(func(key, _ interface{}) bool {
+		if key.(int64) < block {
+			c.Checkpoints.Delete(key)
+		}
+		return true
+	})
+}
+
+// transfers decodes the token Transfer events of a block transactions
+func (c *Client) transfers(blockNum int64, infos []*core.TransactionInfo) []Transfer {
+	list := make([]Transfer, 0)
+	for _, info := range infos {
+		for i, log := range info.GetLog() {
+			from, to, value, ok := parseTransfer(log, c.contract)
+			if !ok {
+				continue
+			}
+			list = append(list, Transfer{
+				TxID:     hex.EncodeToString(info.GetId()),
+				BlockNum: blockNum,
+				LogIndex: i,
+				From:     common.EncodeCheck(append([]byte{0x41}, from...)),
+				To:       common.EncodeCheck(append([]byte{0x41}, to...)),
+				Amount:   value,
+			})
+		}
+	}
+	return list
+}
+
+func (f TransferFilter) validate() error {
+	for _, addr := range []string{f.From, f.To} {
+		if addr == "" {
+			continue
+		}
+		if _, err := common.DecodeCheck(addr); err != nil {
+			return fmt.Errorf("invalid filter address %s: %v", addr, err)
+		}
+	}
+	return nil
+}
+
+func (f TransferFilter) match(t Transfer) bool {
+	if f.From != "" && f.From != t.From {
+		return false
+	}
+	if f.To != "" && f.To != t.To {
+		return false
+	}
+	return f.MinAmount == nil || t.Amount.Cmp(f.MinAmount) >= 0
+}
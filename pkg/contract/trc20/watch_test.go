@@ -0,0 +1,123 @@
+package trc20
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestClientTransfers(t *testing.T) {
+	c, err := NewClient(nil, testToken)
+	require.Nil(t, err)
+
+	list := c.transfers(100, []*core.TransactionInfo{
+		{Id: []byte{0x01}, Log: []*core.TransactionInfo_Log{
+			transferLog(t, testBob, testAlice, testBob, 700),
+			transferLog(t, testToken, testAlice, testBob, 300),
+		}},
+		{Id: []byte{0x02}, Log: []*core.TransactionInfo_Log{
+			transferLog(t, testToken, testBob, testAlice, 50),
+		}},
+	})
+	require.Len(t, list, 2)
+	assert.Equal(t, Transfer{TxID: "01", BlockNum: 100, LogIndex: 1,
+		From: testAlice, To: testBob, Amount: big.NewInt(300)}, list[0])
+	assert.Equal(t, "02", list[1].TxID)
+	assert.Equal(t, 0, list[1].LogIndex)
+
+	assert.True(t, TransferFilter{}.match(list[0]))
+	assert.True(t, TransferFilter{To: testBob, MinAmount: big.NewInt(300)}.match(list[0]))
+	assert.False(t, TransferFilter{To: testBob, MinAmount: big.NewInt(301)}.match(list[0]))
+	assert.False(t, TransferFilter{From: testBob}.match(list[0]))
+	assert.True(t, TransferFilter{From: testBob, To: testAlice}.match(list[1]))
+}
+
+func TestClientLastCheckpoint(t *testing.T) {
+	c, err := NewClient(nil, testToken)
+	require.Nil(t, err)
+	_, ok := c.LastCheckpoint()
+	assert.False(t, ok)
+
+	c.Checkpoints.Store(int64(101), 0)
+	c.Checkpoints.Store(int64(103), 2)
+	c.Checkpoints.Store(int64(102), 1)
+	last, ok := c.LastCheckpoint()
+	assert.True(t, ok)
+	assert.Equal(t, int64(103), last)
+}
+
+func TestWatchTransfersInvalidFilter(t *testing.T) {
+	c, err := NewClient(nil, testToken)
+	require.Nil(t, err)
+
+	transfers, errs := c.WatchTransfers(context.Background(), TransferFilter{To: "not an address"})
+	assert.Error(t, <-errs)
+	_, open := <-transfers
+	assert.False(t, open)
+}
+
+// watchWallet node with a transfer to bob in every block
+type watchWallet struct {
+	api.WalletClient
+	t    *testing.T
+	head int64
+}
+
+func (w *watchWallet) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: w.head}}}, nil
+}
+
+func (w *watchWallet) GetTransactionInfoByBlockNum(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.TransactionInfoList, error) {
+	return &api.TransactionInfoList{TransactionInfo: []*core.TransactionInfo{
+		{Id: []byte{byte(in.Num)}, Log: []*core.TransactionInfo_Log{
+			transferLog(w.t, testToken, testAlice, testBob, in.Num),
+		}},
+	}}, nil
+}
+
+func TestWatchTransfersConfirmations(t *testing.T) {
+	conn := client.NewGrpcClient("")
+	conn.Client = &watchWallet{t: t, head: 130}
+	c, err := NewClient(conn, testToken)
+	require.Nil(t, err)
+	c.pollInterval = time.Millisecond
+	c.Checkpoints.Store(int64(100), 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	transfers, errs := c.WatchTransfers(ctx, TransferFilter{FromBlock: 110})
+	for _, want := range []int64{110, 111} {
+		select {
+		case transfer := <-transfers:
+			assert.Equal(t, want, transfer.BlockNum)
+		case err := <-errs:
+			t.Fatal(err)
+		}
+	}
+	// 130 - DefaultConfirmations is the last block read
+	select {
+	case transfer := <-transfers:
+		t.Fatalf("unconfirmed block %d emitted", transfer.BlockNum)
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	for range transfers {
+	}
+
+	last, ok := c.LastCheckpoint()
+	assert.True(t, ok)
+	assert.Equal(t, int64(111), last)
+	count := 0
+	c.Checkpoints.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,226 @@
+// Package eventserver queries contract events indexed by a TronGrid style
+// event server over its HTTP API. The server offers no push feed, Subscribe
+// polls it, by default every DefaultPollInterval.
+package eventserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEndpoint public TronGrid event query service
+const DefaultEndpoint = "https://api.trongrid.io"
+
+// DefaultPollInterval time between Subscribe queries, about one block
+const DefaultPollInterval = 3 * time.Second
+
+// pageLimit max events returned per request by the event service
+const pageLimit = 200
+
+// Event contract event as indexed by the event server, parameters are
+// already decoded by the server
+type Event struct {
+	BlockNumber     int64             `json:"block_number"`
+	BlockTimestamp  int64             `json:"block_timestamp"`
+	CallerAddress   string            `json:"caller_contract_address"`
+	ContractAddress string            `json:"contract_address"`
+	EventIndex      int               `json:"event_index"`
+	EventName       string            `json:"event_name"`
+	Event           string            `json:"event"`
+	TransactionID   string            `json:"transaction_id"`
+	Result          map[string]string `json:"result"`
+	ResultType      map[string]string `json:"result_type"`
+}
+
+// Filter narrows event queries
+type Filter struct {
+	EventName     string
+	BlockNumber   int64
+	MinTimestamp  int64
+	OnlyConfirmed bool
+}
+
+// Client event server connection
+type Client struct {
+	endpoint     string
+	apiKey       string
+	httpClient   *http.Client
+	pollInterval time.Duration
+}
+
+// NewClient creates an event server client, an empty endpoint uses DefaultEndpoint
+func NewClient(endpoint string, options ...func(*Client)) *Client {
+	if len(endpoint) == 0 {
+		endpoint = DefaultEndpoint
+	}
+	c := &Client{
+		endpoint:     strings.TrimRight(endpoint, "/"),
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		pollInterval: DefaultPollInterval,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithAPIKey sends TRON-PRO-API-KEY on every request
+func WithAPIKey(apiKey string) func(*Client) {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithHTTPClient replaces the default http client
+func WithHTTPClient(httpClient *http.Client) func(*Client) {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithPollInterval how often Subscribe asks the server for new events,
+// defaults to DefaultPollInterval
+func WithPollInterval(interval time.Duration) func(*Client) {
+	return func(c *Client) {
+		c.pollInterval = interval
+	}
+}
+
+type eventsResponse struct {
+	Data    []Event `json:"data"`
+	Success bool    `json:"success"`
+	Error   string  `json:"error"`
+	Meta    struct {
+		Fingerprint string `json:"fingerprint"`
+	} `json:"meta"`
+}
+
+// ContractEvents returns events emitted by contract in ascending block order,
+// all pages are followed
+func (c *Client) ContractEvents(ctx context.Context, contract string, filter Filter) ([]Event, error) {
+	var (
+		events      []Event
+		fingerprint string
+	)
+	for {
+		resp, err := c.contractEventsPage(ctx, contract, filter, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, resp.Data...)
+		if len(resp.Meta.Fingerprint) == 0 || len(resp.Data) == 0 {
+			return events, nil
+		}
+		fingerprint = resp.Meta.Fingerprint
+	}
+}
+
+func (c *Client) contractEventsPage(ctx context.Context, contract string, filter Filter, fingerprint string) (*eventsResponse, error) {
+	query := url.Values{}
+	query.Set("order_by", "block_timestamp,asc")
+	query.Set("limit", strconv.Itoa(pageLimit))
+	if len(filter.EventName) > 0 {
+		query.Set("event_name", filter.EventName)
+	}
+	if filter.BlockNumber > 0 {
+		query.Set("block_number", strconv.FormatInt(filter.BlockNumber, 10))
+	}
+	if filter.MinTimestamp > 0 {
+		query.Set("min_block_timestamp", strconv.FormatInt(filter.MinTimestamp, 10))
+	}
+	if filter.OnlyConfirmed {
+		query.Set("only_confirmed", "true")
+	}
+	if len(fingerprint) > 0 {
+		query.Set("fingerprint", fingerprint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/v1/contracts/%s/events?%s", c.endpoint, url.PathEscape(contract), query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if len(c.apiKey) > 0 {
+		req.Header.Set("TRON-PRO-API-KEY", c.apiKey)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("event server: %s", res.Status)
+	}
+	resp := &eventsResponse{}
+	if err = json.NewDecoder(res.Body).Decode(resp); err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("event server: %s", resp.Error)
+	}
+	return resp, nil
+}
+
+// Subscribe polls the event server for new events emitted by contract, every
+// poll interval (see WithPollInterval), until ctx is cancelled. It is not a
+// push subscription, events arrive up to one interval after the server
+// indexed them. Events start at filter.MinTimestamp, or now when unset. Both
+// channels are closed on return, the subscription stops at the first error.
+func (c *Client) Subscribe(ctx context.Context, contract string, filter Filter) (<-chan Event, <-chan error) {
+	eventChan := make(chan Event)
+	errChan := make(chan error, 1)
+	if filter.MinTimestamp == 0 {
+		filter.MinTimestamp = time.Now().UnixMilli()
+	}
+	filter.BlockNumber = 0
+
+	go func() {
+		defer close(eventChan)
+		defer close(errChan)
+
+		// events at the last seen timestamp are returned again by the next
+		// query, remember them to send each event once
+		seen := make(map[string]bool)
+		ticker := time.NewTicker(c.pollInterval)
+		defer ticker.Stop()
+		for {
+			events, err := c.ContractEvents(ctx, contract, filter)
+			if err != nil {
+				if ctx.Err() == nil {
+					errChan <- err
+				}
+				return
+			}
+			for _, event := range events {
+				key := fmt.Sprintf("%s:%d", event.TransactionID, event.EventIndex)
+				if seen[key] || event.BlockTimestamp < filter.MinTimestamp {
+					continue
+				}
+				if event.BlockTimestamp > filter.MinTimestamp {
+					filter.MinTimestamp = event.BlockTimestamp
+					seen = make(map[string]bool)
+				}
+				seen[key] = true
+				select {
+				case eventChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return eventChan, errChan
+}
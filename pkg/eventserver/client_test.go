@@ -0,0 +1,80 @@
+package eventserver_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/eventserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractEventsPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/contracts/TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t/events", r.URL.Path)
+		assert.Equal(t, "Transfer", r.URL.Query().Get("event_name"))
+		assert.Equal(t, "key", r.Header.Get("TRON-PRO-API-KEY"))
+		if r.URL.Query().Get("fingerprint") == "" {
+			fmt.Fprint(w, `{"success":true,"meta":{"fingerprint":"next"},"data":[
+				{"transaction_id":"aa","event_index":0,"event_name":"Transfer","block_timestamp":1,
+				 "result":{"value":"10"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"meta":{},"data":[
+			{"transaction_id":"bb","event_index":1,"event_name":"Transfer","block_timestamp":2}]}`)
+	}))
+	defer server.Close()
+
+	c := eventserver.NewClient(server.URL, eventserver.WithAPIKey("key"))
+	events, err := c.ContractEvents(context.Background(), "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		eventserver.Filter{EventName: "Transfer"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "aa", events[0].TransactionID)
+	assert.Equal(t, "10", events[0].Result["value"])
+	assert.Equal(t, "bb", events[1].TransactionID)
+}
+
+func TestSubscribeSkipsSeenEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the server keeps returning the event at min_block_timestamp
+		fmt.Fprint(w, `{"success":true,"meta":{},"data":[
+			{"transaction_id":"aa","event_index":0,"block_timestamp":5},
+			{"transaction_id":"bb","event_index":0,"block_timestamp":7}]}`)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := eventserver.NewClient(server.URL, eventserver.WithPollInterval(10*time.Millisecond))
+	events, errs := c.Subscribe(ctx, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", eventserver.Filter{MinTimestamp: 1})
+
+	var got []string
+	timeout := time.After(100 * time.Millisecond)
+	for len(got) < 3 {
+		select {
+		case event := <-events:
+			got = append(got, event.TransactionID)
+		case err := <-errs:
+			require.NoError(t, err)
+		case <-timeout:
+			assert.Equal(t, []string{"aa", "bb"}, got)
+			return
+		}
+	}
+	t.Fatalf("duplicated events: %v", got)
+}
+
+func TestServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":false,"error":"invalid address"}`)
+	}))
+	defer server.Close()
+
+	_, err := eventserver.NewClient(server.URL).ContractEvents(context.Background(), "x", eventserver.Filter{})
+	assert.EqualError(t, err, "event server: invalid address")
+}
@@ -0,0 +1,48 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+// Account key pair and address generated in memory, nothing is written to
+// the keystore. Callers persisting the key should encrypt it, e.g. with
+// keystore.EncryptKey, and call Zero once done with it.
+type Account struct {
+	PrivateKey *btcec.PrivateKey
+	PublicKey  *btcec.PublicKey
+	Address    address.Address
+}
+
+// NewAccount generates a random account
+func NewAccount() (*Account, error) {
+	sk, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		PrivateKey: sk,
+		PublicKey:  sk.PubKey(),
+		Address:    address.PubkeyToAddress(sk.ToECDSA().PublicKey),
+	}, nil
+}
+
+// ECDSA private key in the form used by keystore and signing helpers
+func (a *Account) ECDSA() *ecdsa.PrivateKey {
+	return a.PrivateKey.ToECDSA()
+}
+
+// Dump HEX encoded keys
+func (a *Account) Dump() *Dump {
+	return EncodeHex(a.PrivateKey, a.PublicKey)
+}
+
+// Zero clears the private key from memory, the account can not sign afterwards
+func (a *Account) Zero() {
+	if a.PrivateKey != nil {
+		a.PrivateKey.Zero()
+		a.PrivateKey = nil
+	}
+}
@@ -0,0 +1,24 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccount(t *testing.T) {
+	acc, err := keys.NewAccount()
+	require.Nil(t, err)
+	assert.Equal(t, address.PubkeyToAddress(acc.ECDSA().PublicKey), acc.Address)
+	assert.Len(t, acc.Address.String(), address.AddressLengthBase58)
+
+	other, err := keys.NewAccount()
+	require.Nil(t, err)
+	assert.NotEqual(t, acc.Address, other.Address)
+
+	acc.Zero()
+	assert.Nil(t, acc.PrivateKey)
+}
@@ -0,0 +1,13 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/tip712"
+)
+
+// SignTypedData signs a parsed TIP-712 document with privateKey, the
+// signature matches TronLink signTypedData, see tip712.SignTypedData
+func SignTypedData(privateKey *ecdsa.PrivateKey, typed *tip712.TypedData) ([]byte, error) {
+	return tip712.SignTypedData(privateKey, typed.Domain, typed.Types, typed.PrimaryType, typed.Message)
+}
@@ -0,0 +1,35 @@
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/keys"
+	"github.com/fbsobreira/gotron-sdk/pkg/tip712"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignTypedData(t *testing.T) {
+	acc, err := keys.NewAccount()
+	require.Nil(t, err)
+
+	typed, err := tip712.ParseTypedData([]byte(`{
+		"types": {
+			"EIP712Domain": [{"name": "name", "type": "string"}],
+			"Mail": [{"name": "contents", "type": "string"}]
+		},
+		"primaryType": "Mail",
+		"domain": {"name": "Ether Mail"},
+		"message": {"contents": "Hello, Bob!"}
+	}`))
+	require.Nil(t, err)
+
+	signature, err := keys.SignTypedData(acc.ECDSA(), typed)
+	require.Nil(t, err)
+	require.Len(t, signature, 65)
+
+	ok, err := tip712.VerifyTypedData(acc.Address.String(), typed.Domain, typed.Types,
+		typed.PrimaryType, typed.Message, signature)
+	require.Nil(t, err)
+	assert.True(t, ok)
+}
@@ -1,9 +1,12 @@
 package ledger
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 
 	"golang.org/x/crypto/sha3"
@@ -16,6 +19,13 @@ var (
 	once  sync.Once
 )
 
+// minTIP712Version first Tron app release signing TIP-712 typed data
+var minTIP712Version = [3]int{0, 5, 0}
+
+// ErrTIP712Unsupported is returned when the Tron app on the device is too old
+// to sign typed data
+var ErrTIP712Unsupported = errors.New("ledger tron app does not support typed data, update the firmware")
+
 func getLedger() *NanoS {
 	once.Do(func() {
 		var err error
@@ -87,3 +97,43 @@ func SignTx(tx []byte) ([]byte, error) {
 	//return sig, nil
 	return nil, nil
 }
+
+// SignTypedDataHash signs TIP-712 typed data from its domain separator and
+// message hash. The 65 byte signature ends with v as 27 or 28.
+func SignTypedDataHash(domainSeparator, messageHash []byte) ([]byte, error) {
+	n := getLedger()
+	version, err := n.GetVersion()
+	if err != nil {
+		return nil, err
+	}
+	if !versionAtLeast(version, minTIP712Version) {
+		return nil, fmt.Errorf("%w: app %s, %d.%d.%d required", ErrTIP712Unsupported, version,
+			minTIP712Version[0], minTIP712Version[1], minTIP712Version[2])
+	}
+	sig, err := n.SignTIP712Hash(domainSeparator, messageHash)
+	if err != nil {
+		return nil, err
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig[:], nil
+}
+
+// versionAtLeast compares a vX.Y.Z version returned by GetVersion
+func versionAtLeast(version string, min [3]int) bool {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) != 3 {
+		return false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return false
+		}
+		if n != min[i] {
+			return n > min[i]
+		}
+	}
+	return true
+}
@@ -174,6 +174,7 @@ const (
 	cmdGetPublicKey = 0x02
 	cmdSignStaking  = 0x04
 	cmdSignTx       = 0x08
+	cmdSignTIP712   = 0x0c
 
 	p1First = 0x0
 	p1More  = 0x80
@@ -227,6 +228,32 @@ func (n *NanoS) SignTxn(txn []byte) (sig [signatureSize]byte, err error) {
 	return
 }
 
+// defaultPath m/44'/195'/0'/0/0, the first Tron account
+var defaultPath = []uint32{0x8000002c, 0x800000c3, 0x80000000, 0, 0}
+
+// SignTIP712Hash signs typed data given its domain separator and message
+// hash, both 32 bytes, with the account at defaultPath
+func (n *NanoS) SignTIP712Hash(domainSeparator, messageHash []byte) (sig [signatureSize]byte, err error) {
+	if len(domainSeparator) != 32 || len(messageHash) != 32 {
+		return [signatureSize]byte{}, errInvalidParam
+	}
+	payload := []byte{byte(len(defaultPath))}
+	for _, index := range defaultPath {
+		payload = binary.BigEndian.AppendUint32(payload, index)
+	}
+	payload = append(payload, domainSeparator...)
+	payload = append(payload, messageHash...)
+
+	resp, err := n.Exchange(cmdSignTIP712, p1First, 0, payload)
+	if err != nil {
+		return [signatureSize]byte{}, err
+	}
+	if copy(sig[:], resp) != len(sig) {
+		return [signatureSize]byte{}, errors.New("signature has wrong length")
+	}
+	return
+}
+
 // OpenNanoS start process
 func OpenNanoS() (*NanoS, error) {
 	const (
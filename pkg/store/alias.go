@@ -0,0 +1,103 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+const aliasesFileName = "aliases.json"
+
+// ErrAliasNotFound is returned when the alias is not defined
+var ErrAliasNotFound = fmt.Errorf("alias not found")
+
+func aliasesPath() string {
+	return path.Join(DefaultLocation(), aliasesFileName)
+}
+
+// Aliases returns user defined aliases and their Base58 address
+func Aliases() (map[string]string, error) {
+	aliases := make(map[string]string)
+	data, err := ioutil.ReadFile(aliasesPath())
+	if os.IsNotExist(err) {
+		return aliases, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", aliasesFileName, err)
+	}
+	return aliases, nil
+}
+
+// AliasNames returns the sorted list of defined aliases
+func AliasNames() ([]string, error) {
+	aliases, err := Aliases()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SetAlias defines or replaces alias for a Base58 address
+func SetAlias(alias, addr string) error {
+	if alias == "" {
+		return fmt.Errorf("empty alias")
+	}
+	if DoesNamedAccountExist(alias) {
+		return fmt.Errorf("alias %s conflicts with local account name", alias)
+	}
+	if _, err := address.Base58ToAddress(addr); err != nil {
+		return fmt.Errorf("address not valid: %s", addr)
+	}
+	aliases, err := Aliases()
+	if err != nil {
+		return err
+	}
+	aliases[alias] = addr
+	return saveAliases(aliases)
+}
+
+// RemoveAlias deletes an alias
+func RemoveAlias(alias string) error {
+	aliases, err := Aliases()
+	if err != nil {
+		return err
+	}
+	if _, ok := aliases[alias]; !ok {
+		return ErrAliasNotFound
+	}
+	delete(aliases, alias)
+	return saveAliases(aliases)
+}
+
+// AddressFromAlias returns the Base58 address of an alias
+func AddressFromAlias(alias string) (string, error) {
+	aliases, err := Aliases()
+	if err != nil {
+		return "", err
+	}
+	if addr, ok := aliases[alias]; ok {
+		return addr, nil
+	}
+	return "", ErrAliasNotFound
+}
+
+func saveAliases(aliases map[string]string) error {
+	data, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(aliasesPath(), data, 0600)
+}
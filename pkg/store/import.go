@@ -0,0 +1,41 @@
+package store
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+)
+
+// ErrDuplicateKey is returned when the key being imported is already stored
+// under another account name
+var ErrDuplicateKey = fmt.Errorf("key already in the store")
+
+// ImportHexPrivKey stores the raw secp256k1 private key hexKey, 32 bytes with
+// or without 0x, as account name encrypted with passphrase
+func ImportHexPrivKey(name, hexKey, passphrase string) error {
+	if name == "" {
+		return fmt.Errorf("account name required")
+	}
+	if DoesNamedAccountExist(name) {
+		return fmt.Errorf("account %s already exists", name)
+	}
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid HEX private key: %v", err)
+	}
+	if len(keyBytes) != common.Secp256k1PrivateKeyBytesLength {
+		return common.ErrBadKeyLength
+	}
+
+	sk, _ := btcec.PrivKeyFromBytes(keyBytes)
+	addr := address.PubkeyToAddress(sk.ToECDSA().PublicKey).String()
+	if FromAddress(addr) != nil {
+		return fmt.Errorf("%w: %s", ErrDuplicateKey, addr)
+	}
+	_, err = FromAccountName(name).ImportECDSA(sk.ToECDSA(), passphrase)
+	return err
+}
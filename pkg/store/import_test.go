@@ -0,0 +1,39 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTempHome points the key store to an empty home directory for the test
+func useTempHome(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	homedir.Reset()
+	t.Cleanup(homedir.Reset)
+}
+
+func TestImportHexPrivKey(t *testing.T) {
+	useTempHome(t)
+
+	// private key 1, whose Ethereum address is 0x7e5f...5bdf
+	key := "0x" + strings.Repeat("0", 63) + "1"
+	require.Nil(t, ImportHexPrivKey("one", key, "secret"))
+	addr, err := AddressFromAccountName("one")
+	require.Nil(t, err)
+	assert.Equal(t, address.HexToAddress("417e5f4552091a69125d5dfcb7b8c2659029395bdf").String(), addr)
+
+	err = ImportHexPrivKey("again", key, "secret")
+	assert.True(t, errors.Is(err, ErrDuplicateKey))
+	assert.Equal(t, common.ErrBadKeyLength, ImportHexPrivKey("short", "0x0102", "secret"))
+	assert.NotNil(t, ImportHexPrivKey("bad", strings.Repeat("zz", 32), "secret"))
+	assert.False(t, DoesNamedAccountExist("again"))
+	assert.False(t, DoesNamedAccountExist("short"))
+	assert.False(t, DoesNamedAccountExist("bad"))
+}
@@ -70,13 +70,16 @@ func DoesNamedAccountExist(name string) bool {
 	return false
 }
 
-// AddressFromAccountName Returns address for account name if exists
+// AddressFromAccountName Returns address for account name or alias if exists
 func AddressFromAccountName(name string) (string, error) {
 	ks := FromAccountName(name)
 	// FIXME: Assume 1 account per keystore for now
 	for _, account := range ks.Accounts() {
 		return account.Address.String(), nil
 	}
+	if addr, err := AddressFromAlias(name); err == nil {
+		return addr, nil
+	}
 	return "", fmt.Errorf("keystore not found")
 }
 
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	c "github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// mnemonicsDirName kept apart from the keystore directories, which only hold key files
+const mnemonicsDirName = "mnemonics"
+
+// ErrMnemonicNotStored is returned for accounts imported without a mnemonic
+// or created without asking to store it
+var ErrMnemonicNotStored = fmt.Errorf("mnemonic not stored for account")
+
+func mnemonicPath(name string) string {
+	uDir, _ := homedir.Dir()
+	return path.Join(uDir, c.DefaultConfigDirName, mnemonicsDirName, name+".json")
+}
+
+// StoreMnemonic saves the account mnemonic encrypted with the account passphrase
+func StoreMnemonic(name, mnemonic, passphrase string) error {
+	cj, err := keystore.EncryptDataV3([]byte(mnemonic), []byte(passphrase),
+		keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cj)
+	if err != nil {
+		return err
+	}
+	p := mnemonicPath(name)
+	if err := os.MkdirAll(path.Dir(p), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, data, 0600)
+}
+
+// ExportMnemonic decrypts the mnemonic stored for the account
+func ExportMnemonic(name, passphrase string) (string, error) {
+	data, err := ioutil.ReadFile(mnemonicPath(name))
+	if os.IsNotExist(err) {
+		return "", ErrMnemonicNotStored
+	}
+	if err != nil {
+		return "", err
+	}
+	var cj keystore.CryptoJSON
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return "", fmt.Errorf("invalid mnemonic file: %v", err)
+	}
+	mnemonic, err := keystore.DecryptDataV3(cj, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return string(mnemonic), nil
+}
+
+// RemoveMnemonic deletes the stored mnemonic of an account, if any
+func RemoveMnemonic(name string) error {
+	err := os.Remove(mnemonicPath(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+// Resolver maps a name to an address, e.g. an ENS-like registry or an address book
+type Resolver interface {
+	Resolve(name string) (address.Address, error)
+}
+
+// LocalResolver resolves names from the local keystore accounts
+type LocalResolver struct{}
+
+// Resolve account name into its keystore address
+func (LocalResolver) Resolve(name string) (address.Address, error) {
+	addr, err := AddressFromAccountName(name)
+	if err != nil {
+		return nil, err
+	}
+	return address.Base58ToAddress(addr)
+}
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = []Resolver{LocalResolver{}}
+)
+
+// RegisterResolver adds a resolver, resolvers are consulted in registration
+// order after the local keystore
+func RegisterResolver(r Resolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers = append(resolvers, r)
+}
+
+// ResolveAddress returns the address of the first resolver that knows name
+func ResolveAddress(name string) (address.Address, error) {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	for _, r := range resolvers {
+		if addr, err := r.Resolve(name); err == nil {
+			return addr, nil
+		}
+	}
+	return nil, fmt.Errorf("could not resolve name: %s", name)
+}
@@ -0,0 +1,25 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/pkg/errors"
+)
+
+// SignData signs data with the stored account accountName using the TRON
+// message prefix, keccak256("\x19TRON Signed Message:\n" + len + data), the
+// scheme of `account sign`, so the signature never matches a transaction.
+// The key is decrypted for this signature only.
+func SignData(accountName, passphrase string, data []byte) ([]byte, error) {
+	ks := FromAccountName(accountName)
+	accounts := ks.Accounts()
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("keystore not found for account %s", accountName)
+	}
+	signature, err := ks.SignHashWithPassphrase(accounts[0], passphrase, keystore.TextHash(data))
+	if err == keystore.ErrDecrypt {
+		return nil, errors.Wrap(ErrNoUnlockBadPassphrase, err.Error())
+	}
+	return signature, err
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignData(t *testing.T) {
+	useTempHome(t)
+
+	const hexKey = "b5a4cea271ff424d7c31dc12a3e43e401df7a40d7412a15750f3f0b6b5449a28"
+	require.Nil(t, ImportHexPrivKey("signer", hexKey, "secret"))
+	want, err := AddressFromAccountName("signer")
+	require.Nil(t, err)
+
+	data := []byte("hello tron")
+	signature, err := SignData("signer", "secret", data)
+	require.Nil(t, err)
+	require.Len(t, signature, 65)
+
+	pub, err := crypto.SigToPub(keystore.TextHash(data), signature)
+	require.Nil(t, err)
+	assert.Equal(t, want, address.PubkeyToAddress(*pub).String())
+
+	_, err = SignData("signer", "wrong", data)
+	assert.Equal(t, ErrNoUnlockBadPassphrase, errors.Cause(err))
+	_, err = SignData("missing", "secret", data)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,498 @@
+// Package tip712 hashes and signs typed structured data following TIP-712,
+// the Tron flavour of EIP-712 implemented by TronLink signTypedData. It only
+// differs from EIP-712 in address values, which are Tron addresses encoded as
+// their 20 byte form, and in the trcToken type, encoded as uint256.
+package tip712
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+)
+
+// Chain IDs used in the domain, the last 4 bytes of each network genesis block hash
+const (
+	MainnetChainID = 0x2b6653dc
+	ShastaChainID  = 0x94a9059e
+	NileChainID    = 0xcd8690dc
+)
+
+// domainType name of the domain struct type
+const domainType = "EIP712Domain"
+
+// Field member of a struct type
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types struct type definitions by name. EIP712Domain, when given, is used as
+// declared, otherwise it is built from the Domain.
+type Types map[string][]Field
+
+// Domain separates signatures of different dApps and networks. Unless Types
+// declares EIP712Domain, empty fields are left out of the domain type.
+type Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract string
+	Salt              []byte
+}
+
+// fields returns the domain type made of the fields that are set
+func (d Domain) fields() []Field {
+	fields := make([]Field, 0, 5)
+	if d.Name != "" {
+		fields = append(fields, Field{Name: "name", Type: "string"})
+	}
+	if d.Version != "" {
+		fields = append(fields, Field{Name: "version", Type: "string"})
+	}
+	if d.ChainID != nil {
+		fields = append(fields, Field{Name: "chainId", Type: "uint256"})
+	}
+	if d.VerifyingContract != "" {
+		fields = append(fields, Field{Name: "verifyingContract", Type: "address"})
+	}
+	if len(d.Salt) > 0 {
+		fields = append(fields, Field{Name: "salt", Type: "bytes32"})
+	}
+	return fields
+}
+
+// values returns the domain values, strings even when empty as a declared
+// domain type may hold them
+func (d Domain) values() map[string]interface{} {
+	values := map[string]interface{}{
+		"name":    d.Name,
+		"version": d.Version,
+	}
+	if d.ChainID != nil {
+		values["chainId"] = d.ChainID
+	}
+	if d.VerifyingContract != "" {
+		values["verifyingContract"] = d.VerifyingContract
+	}
+	if len(d.Salt) > 0 {
+		values["salt"] = d.Salt
+	}
+	return values
+}
+
+// HashTypedData returns the digest signed for message of type primaryType:
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+func HashTypedData(domain Domain, types Types, primaryType string, message map[string]interface{}) ([]byte, error) {
+	domainSeparator, messageHash, err := HashTypedDataParts(domain, types, primaryType, message)
+	if err != nil {
+		return nil, err
+	}
+	digest := append([]byte{0x19, 0x01}, domainSeparator...)
+	return common.Keccak256(append(digest, messageHash...)), nil
+}
+
+// HashTypedDataParts returns the domain separator and hashStruct(message)
+// making up the digest, the form hardware wallets sign typed data from
+func HashTypedDataParts(domain Domain, types Types, primaryType string,
+	message map[string]interface{}) (domainSeparator, messageHash []byte, err error) {
+	all := make(Types, len(types)+1)
+	for name, fields := range types {
+		all[name] = fields
+	}
+	if _, ok := all[domainType]; !ok {
+		all[domainType] = domain.fields()
+	}
+
+	domainSeparator, err = hashStruct(all, domainType, domain.values())
+	if err != nil {
+		return nil, nil, fmt.Errorf("domain: %v", err)
+	}
+	if _, ok := types[primaryType]; !ok {
+		return nil, nil, fmt.Errorf("unknown primary type: %s", primaryType)
+	}
+	messageHash, err = hashStruct(all, primaryType, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return domainSeparator, messageHash, nil
+}
+
+// TypedData the JSON document passed to eth_signTypedData_v4 and TronLink
+// signTypedData
+type TypedData struct {
+	Types       Types
+	PrimaryType string
+	Domain      Domain
+	Message     map[string]interface{}
+}
+
+// ParseTypedData decodes a typed data JSON document. The EIP712Domain type
+// is kept as declared, the domain hashes like it does in TronLink.
+func ParseTypedData(data []byte) (*TypedData, error) {
+	var doc struct {
+		Types       Types                  `json:"types"`
+		PrimaryType string                 `json:"primaryType"`
+		Domain      map[string]interface{} `json:"domain"`
+		Message     map[string]interface{} `json:"message"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep integers above 2^53 exact
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid typed data: %v", err)
+	}
+	if doc.PrimaryType == "" {
+		return nil, fmt.Errorf("invalid typed data: no primaryType")
+	}
+
+	typed := &TypedData{
+		Types:       doc.Types,
+		PrimaryType: doc.PrimaryType,
+		Message:     doc.Message,
+	}
+	for key, value := range doc.Domain {
+		var err error
+		switch key {
+		case "name":
+			typed.Domain.Name, err = toString(value)
+		case "version":
+			typed.Domain.Version, err = toString(value)
+		case "chainId":
+			typed.Domain.ChainID, err = toBigInt(value)
+		case "verifyingContract":
+			typed.Domain.VerifyingContract, err = toString(value)
+		case "salt":
+			typed.Domain.Salt, err = toBytes(value)
+		default:
+			err = fmt.Errorf("unsupported field")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("domain %s: %v", key, err)
+		}
+	}
+	return typed, nil
+}
+
+// Hash returns the digest to sign, see HashTypedData
+func (t *TypedData) Hash() ([]byte, error) {
+	return HashTypedData(t.Domain, t.Types, t.PrimaryType, t.Message)
+}
+
+func toString(value interface{}) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("expected string, got %T", value)
+	}
+	return s, nil
+}
+
+// SignTypedData signs message with privateKey, the 65 byte signature ends
+// with v as 27 or 28 like the ones produced by TronLink
+func SignTypedData(
+	privateKey *ecdsa.PrivateKey,
+	domain Domain,
+	types Types,
+	primaryType string,
+	message map[string]interface{},
+) ([]byte, error) {
+	hash, err := HashTypedData(domain, types, primaryType, message)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(hash, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+// VerifyTypedData reports whether signature over message was produced by
+// signer, a base58 address. v is accepted as 0/1 or 27/28.
+func VerifyTypedData(
+	signer string,
+	domain Domain,
+	types Types,
+	primaryType string,
+	message map[string]interface{},
+	signature []byte,
+) (bool, error) {
+	if len(signature) != 65 {
+		return false, fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+	want, err := address.Base58ToAddress(signer)
+	if err != nil {
+		return false, err
+	}
+	hash, err := HashTypedData(domain, types, primaryType, message)
+	if err != nil {
+		return false, err
+	}
+	rsv := common.CopyBytes(signature)
+	if rsv[64] >= 27 {
+		rsv[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, rsv)
+	if err != nil {
+		return false, nil
+	}
+	return address.PubkeyToAddress(*pub).String() == want.String(), nil
+}
+
+// hashStruct keccak256(typeHash ‖ encodeData(data))
+func hashStruct(types Types, typeName string, data map[string]interface{}) ([]byte, error) {
+	encoded := common.Keccak256([]byte(encodeType(types, typeName)))
+	for _, field := range types[typeName] {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: missing field %s", typeName, field.Name)
+		}
+		enc, err := encodeValue(types, field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", typeName, field.Name, err)
+		}
+		encoded = append(encoded, enc...)
+	}
+	return common.Keccak256(encoded), nil
+}
+
+// encodeType e.g. Mail(Person from,Person to,string contents)Person(string name,address wallet),
+// referenced types follow the primary one sorted by name
+func encodeType(types Types, primaryType string) string {
+	deps := map[string]bool{}
+	collectDeps(types, primaryType, deps)
+	delete(deps, primaryType)
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range append([]string{primaryType}, names...) {
+		sb.WriteString(name + "(")
+		for i, field := range types[name] {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(field.Type + " " + field.Name)
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+func collectDeps(types Types, typeName string, deps map[string]bool) {
+	typeName = baseType(typeName)
+	if _, ok := types[typeName]; !ok || deps[typeName] {
+		return
+	}
+	deps[typeName] = true
+	for _, field := range types[typeName] {
+		collectDeps(types, field.Type, deps)
+	}
+}
+
+// baseType strips array suffixes, Person[][2] is Person
+func baseType(typeName string) string {
+	if i := strings.Index(typeName, "["); i > 0 {
+		return typeName[:i]
+	}
+	return typeName
+}
+
+// encodeValue returns the 32 byte encoding of value as typeName
+func encodeValue(types Types, typeName string, value interface{}) ([]byte, error) {
+	if strings.HasSuffix(typeName, "]") {
+		open := strings.LastIndex(typeName, "[")
+		elemType := typeName[:open]
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("expected array for %s, got %T", typeName, value)
+		}
+		if size := typeName[open+1 : len(typeName)-1]; size != "" {
+			n, err := strconv.Atoi(size)
+			if err != nil {
+				return nil, fmt.Errorf("invalid type %s", typeName)
+			}
+			if rv.Len() != n {
+				return nil, fmt.Errorf("expected %d items for %s, got %d", n, typeName, rv.Len())
+			}
+		}
+		encoded := make([]byte, 0, rv.Len()*32)
+		for i := 0; i < rv.Len(); i++ {
+			enc, err := encodeValue(types, elemType, rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %v", i, err)
+			}
+			encoded = append(encoded, enc...)
+		}
+		return common.Keccak256(encoded), nil
+	}
+
+	if _, ok := types[typeName]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected object for %s, got %T", typeName, value)
+		}
+		return hashStruct(types, typeName, data)
+	}
+
+	switch {
+	case typeName == "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		return common.Keccak256([]byte(s)), nil
+	case typeName == "bytes":
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return common.Keccak256(b), nil
+	case typeName == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+		encoded := make([]byte, 32)
+		if b {
+			encoded[31] = 1
+		}
+		return encoded, nil
+	case typeName == "address":
+		addr, err := toAddress(value)
+		if err != nil {
+			return nil, err
+		}
+		return common.LeftPadBytes(addr, 32), nil
+	case typeName == "trcToken":
+		return encodeInt("uint256", value)
+	case strings.HasPrefix(typeName, "bytes"):
+		n, err := strconv.Atoi(typeName[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("invalid type %s", typeName)
+		}
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > n {
+			return nil, fmt.Errorf("%d bytes do not fit %s", len(b), typeName)
+		}
+		return common.RightPadBytes(b, 32), nil
+	case strings.HasPrefix(typeName, "uint"), strings.HasPrefix(typeName, "int"):
+		return encodeInt(typeName, value)
+	}
+	return nil, fmt.Errorf("unknown type %s", typeName)
+}
+
+// encodeInt encodes intN/uintN values, negative ones in two's complement
+func encodeInt(typeName string, value interface{}) ([]byte, error) {
+	signed := strings.HasPrefix(typeName, "int")
+	bits := 256
+	if size := strings.TrimPrefix(strings.TrimPrefix(typeName, "u"), "int"); size != "" {
+		var err error
+		if bits, err = strconv.Atoi(size); err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("invalid type %s", typeName)
+		}
+	}
+	n, err := toBigInt(value)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	min := new(big.Int)
+	if signed {
+		limit.Rsh(limit, 1)
+		min.Neg(limit)
+	}
+	if n.Cmp(min) < 0 || n.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%s out of range for %s", n.String(), typeName)
+	}
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return common.LeftPadBytes(n.Bytes(), 32), nil
+}
+
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return nil, fmt.Errorf("invalid integer %v", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return toBigInt(string(v))
+	case string:
+		n, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", value)
+}
+
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if !common.Has0xPrefix(v) {
+			return nil, fmt.Errorf("expected 0x prefixed hex, got %q", v)
+		}
+		return common.FromHex(v)
+	}
+	return nil, fmt.Errorf("expected bytes, got %T", value)
+}
+
+// toAddress accepts base58 addresses and 21 (41 prefixed) or 20 byte hex ones
+func toAddress(value interface{}) ([]byte, error) {
+	var b []byte
+	switch v := value.(type) {
+	case address.Address:
+		b = v.Bytes()
+	case string:
+		var err error
+		if strings.HasPrefix(v, "T") {
+			b, err = common.DecodeCheck(v)
+		} else {
+			b, err = common.FromHex(v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %v", v, err)
+		}
+	default:
+		return nil, fmt.Errorf("expected address, got %T", value)
+	}
+	if len(b) == address.AddressLength && b[0] == address.TronBytePrefix {
+		b = b[1:]
+	}
+	if len(b) != 20 {
+		return nil, fmt.Errorf("invalid address length: %d", len(b))
+	}
+	return b, nil
+}
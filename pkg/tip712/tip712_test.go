@@ -0,0 +1,129 @@
+package tip712
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mailTypes = Types{
+	"Person": {{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}},
+	"Mail":   {{Name: "from", Type: "Person"}, {Name: "to", Type: "Person"}, {Name: "contents", Type: "string"}},
+}
+
+func mailMessage(from, to string) map[string]interface{} {
+	return map[string]interface{}{
+		"from":     map[string]interface{}{"name": "Cow", "wallet": from},
+		"to":       map[string]interface{}{"name": "Bob", "wallet": to},
+		"contents": "Hello, Bob!",
+	}
+}
+
+// EIP-712 reference example, hex addresses hash like their Tron form
+func TestHashTypedData(t *testing.T) {
+	domain := Domain{
+		Name:              "Ether Mail",
+		Version:           "1",
+		ChainID:           big.NewInt(1),
+		VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+	}
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)",
+		encodeType(mailTypes, "Mail"))
+
+	hash, err := HashTypedData(domain, mailTypes, "Mail", mailMessage(
+		"0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"))
+	require.Nil(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2",
+		common.BytesToHexString(hash))
+
+	from := address.HexToAddress("41CD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826").String()
+	to := address.HexToAddress("41bBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB").String()
+	domain.VerifyingContract = address.HexToAddress("41CcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC").String()
+	tronHash, err := HashTypedData(domain, mailTypes, "Mail", mailMessage(from, to))
+	require.Nil(t, err)
+	assert.Equal(t, hash, tronHash)
+
+	_, err = HashTypedData(domain, mailTypes, "Mail", map[string]interface{}{"contents": "Hello, Bob!"})
+	assert.EqualError(t, err, "Mail: missing field from")
+}
+
+func TestEncodeInt(t *testing.T) {
+	enc, err := encodeValue(nil, "int8", -1)
+	require.Nil(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0xff}, 32), enc)
+
+	enc, err = encodeValue(nil, "trcToken", "1002000")
+	require.Nil(t, err)
+	assert.Equal(t, common.LeftPadBytes(big.NewInt(1002000).Bytes(), 32), enc)
+
+	_, err = encodeValue(nil, "uint8", 256)
+	assert.EqualError(t, err, "256 out of range for uint8")
+	_, err = encodeValue(nil, "uint256", -1)
+	assert.Error(t, err)
+}
+
+func TestParseTypedData(t *testing.T) {
+	typed, err := ParseTypedData([]byte(`{
+		"types": {
+			"EIP712Domain": [
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"}
+			],
+			"Person": [{"name": "name", "type": "string"}, {"name": "wallet", "type": "address"}],
+			"Mail": [{"name": "from", "type": "Person"}, {"name": "to", "type": "Person"}, {"name": "contents", "type": "string"}]
+		},
+		"primaryType": "Mail",
+		"domain": {
+			"name": "Ether Mail",
+			"version": "1",
+			"chainId": 1,
+			"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+		},
+		"message": {
+			"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!"
+		}
+	}`))
+	require.Nil(t, err)
+	assert.Equal(t, "Mail", typed.PrimaryType)
+	assert.Equal(t, int64(1), typed.Domain.ChainID.Int64())
+
+	hash, err := typed.Hash()
+	require.Nil(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2",
+		common.BytesToHexString(hash))
+
+	_, err = ParseTypedData([]byte(`{"types": {}, "domain": {}, "message": {}}`))
+	assert.EqualError(t, err, "invalid typed data: no primaryType")
+}
+
+// the declared domain type is hashed as is, in its order and with empty values
+func TestParseTypedDataDeclaredDomain(t *testing.T) {
+	typed, err := ParseTypedData([]byte(`{
+		"types": {
+			"EIP712Domain": [
+				{"name": "version", "type": "string"},
+				{"name": "name", "type": "string"},
+				{"name": "chainId", "type": "uint256"}
+			],
+			"Mail": [{"name": "contents", "type": "string"}]
+		},
+		"primaryType": "Mail",
+		"domain": {"version": "1", "name": "", "chainId": 0},
+		"message": {"contents": "Hello, Bob!"}
+	}`))
+	require.Nil(t, err)
+
+	hash, err := typed.Hash()
+	require.Nil(t, err)
+	assert.Equal(t, "0xcce079d4fec85e5aec7cba4d6a6506f9b3d97627b7be8743349bd213ca47540f",
+		common.BytesToHexString(hash))
+}